@@ -0,0 +1,94 @@
+package bt
+
+// Blackboard, 黑板对象，以键值对存放行为树节点间共享的实体数据
+type Blackboard struct {
+	data map[string]interface{}
+}
+
+// NewBlackboard, Blackboard 类实例初始化函数
+// 返回值:
+//     Blackboard 类指针
+func NewBlackboard() *Blackboard {
+	return &Blackboard{data: make(map[string]interface{})}
+}
+
+// Set, Blackboard 类设置数据的方法
+// 参数:
+//     key: 键
+//     value: 值
+func (b *Blackboard) Set(key string, value interface{}) {
+	b.data[key] = value
+}
+
+// Get, Blackboard 类获取数据的方法
+// 参数:
+//     key: 键
+// 返回值:
+//     interface{} 类型，键不存在时为 nil
+func (b *Blackboard) Get(key string) interface{} {
+	return b.data[key]
+}
+
+// Has, Blackboard 类判断键是否存在的方法
+// 参数:
+//     key: 键
+// 返回值:
+//     bool 类型
+func (b *Blackboard) Has(key string) bool {
+	_, ok := b.data[key]
+	return ok
+}
+
+// Delete, Blackboard 类删除数据的方法
+// 参数:
+//     key: 键
+func (b *Blackboard) Delete(key string) {
+	delete(b.data, key)
+}
+
+// Bool, Blackboard 类获取布尔值数据的方法
+// 参数:
+//     key: 键
+// 返回值:
+//     bool 类型，键不存在或类型不符时为 false
+func (b *Blackboard) Bool(key string) bool {
+	v, _ := b.data[key].(bool)
+	return v
+}
+
+// Int, Blackboard 类获取整型数据的方法
+// 参数:
+//     key: 键
+// 返回值:
+//     int 类型，键不存在或类型不符时为 0
+func (b *Blackboard) Int(key string) int {
+	v, _ := b.data[key].(int)
+	return v
+}
+
+// Float, Blackboard 类获取浮点数据的方法，兼容 float32 与 int 类型的值
+// 参数:
+//     key: 键
+// 返回值:
+//     float64 类型，键不存在或类型不符时为 0
+func (b *Blackboard) Float(key string) float64 {
+	switch v := b.data[key].(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+// String, Blackboard 类获取字符串数据的方法
+// 参数:
+//     key: 键
+// 返回值:
+//     string 类型，键不存在或类型不符时为空字符串
+func (b *Blackboard) String(key string) string {
+	v, _ := b.data[key].(string)
+	return v
+}
@@ -0,0 +1,106 @@
+// bt 包，该包提供行为树运行时：组合节点（顺序、选择、并行）、装饰节点（取反、重复、冷却、超时）与叶节点（动作、条件、等待），
+// 每棵行为树持有独立的黑板用于存放实体数据，行为树可在代码中构建或从 JSON 配置加载，不依赖渲染
+package bt
+
+// Status, 节点执行状态
+type Status int
+
+const (
+	// Running, 节点执行中，下次更新时继续执行
+	Running Status = iota
+	// Success, 节点执行成功
+	Success
+	// Failure, 节点执行失败
+	Failure
+)
+
+// String, Status 类获取状态名称的方法
+// 返回值:
+//     string 类型
+func (s Status) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	}
+	return "unknown"
+}
+
+// Context, 节点执行上下文对象
+type Context struct {
+	Blackboard *Blackboard
+	// 本次更新的时延
+	Delta float64
+	// 行为树累计运行时长，单位为秒
+	Time float64
+}
+
+// Node, 行为树节点接口对象
+type Node interface {
+	// Tick 执行节点并返回执行状态
+	Tick(ctx *Context) Status
+	// Reset 重置节点（及其子节点）的运行状态，用于中断执行中的节点
+	Reset()
+}
+
+// Tree, 行为树对象
+type Tree struct {
+	Root       Node
+	Blackboard *Blackboard
+	ctx        Context
+	status     Status
+}
+
+// NewTree, Tree 类实例初始化函数
+// 参数:
+//     root: 根节点
+// 返回值:
+//     Tree 类指针，含空黑板
+func NewTree(root Node) *Tree {
+	return &Tree{
+		Root:       root,
+		Blackboard: NewBlackboard(),
+		status:     Failure,
+	}
+}
+
+// Tick, Tree 类执行一次行为树的方法，根节点完成后下次更新将从头执行
+// 参数:
+//     delta: 与上次更新的时延
+// 返回值:
+//     Status 类，根节点执行状态，无根节点时为 Failure
+func (t *Tree) Tick(delta float64) Status {
+	if t.Root == nil {
+		return Failure
+	}
+	t.ctx.Blackboard = t.Blackboard
+	t.ctx.Delta = delta
+	t.ctx.Time += delta
+	t.status = t.Root.Tick(&t.ctx)
+	return t.status
+}
+
+// Reset, Tree 类重置行为树运行状态的方法，黑板数据不受影响
+func (t *Tree) Reset() {
+	if t.Root != nil {
+		t.Root.Reset()
+	}
+	t.status = Failure
+}
+
+// Status, Tree 类获取最近一次执行状态的方法
+// 返回值:
+//     Status 类
+func (t *Tree) Status() Status {
+	return t.status
+}
+
+// Time, Tree 类获取行为树累计运行时长的方法
+// 返回值:
+//     float64 类型，单位为秒
+func (t *Tree) Time() float64 {
+	return t.ctx.Time
+}
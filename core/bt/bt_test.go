@@ -0,0 +1,351 @@
+package bt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// script, 按预设次序返回状态的测试节点，次序用尽后重复最后一个状态
+type script struct {
+	statuses      []Status
+	ticks, resets int
+}
+
+func newScript(statuses ...Status) *script {
+	return &script{statuses: statuses}
+}
+
+func (s *script) Tick(ctx *Context) Status {
+	i := s.ticks
+	if i >= len(s.statuses) {
+		i = len(s.statuses) - 1
+	}
+	s.ticks++
+	return s.statuses[i]
+}
+
+func (s *script) Reset() {
+	s.resets++
+}
+
+// run, 以固定时延连续执行 n 次节点，返回各次状态
+func run(n Node, times int, delta float64) []Status {
+	ctx := &Context{Blackboard: NewBlackboard(), Delta: delta}
+	statuses := make([]Status, 0, times)
+	for i := 0; i < times; i++ {
+		ctx.Time += delta
+		statuses = append(statuses, n.Tick(ctx))
+	}
+	return statuses
+}
+
+func ticks(children []*script) []int {
+	counts := make([]int, len(children))
+	for i, c := range children {
+		counts[i] = c.ticks
+	}
+	return counts
+}
+
+func TestComposites(t *testing.T) {
+	tests := []struct {
+		name     string
+		children [][]Status
+		build    func(children []Node) Node
+		want     []Status
+		ticks    []int
+	}{
+		{
+			name:     "sequence success",
+			children: [][]Status{{Success}, {Success}},
+			build:    func(c []Node) Node { return NewSequence(c...) },
+			want:     []Status{Success},
+			ticks:    []int{1, 1},
+		},
+		{
+			name:     "sequence failure stops",
+			children: [][]Status{{Failure}, {Success}},
+			build:    func(c []Node) Node { return NewSequence(c...) },
+			want:     []Status{Failure},
+			ticks:    []int{1, 0},
+		},
+		{
+			name:     "sequence resumes running child",
+			children: [][]Status{{Success}, {Running, Running, Success}},
+			build:    func(c []Node) Node { return NewSequence(c...) },
+			want:     []Status{Running, Running, Success},
+			ticks:    []int{1, 3},
+		},
+		{
+			name:     "reactive sequence re-checks earlier children",
+			children: [][]Status{{Success}, {Running, Running, Success}},
+			build:    func(c []Node) Node { return &Sequence{Children: c, Reactive: true} },
+			want:     []Status{Running, Running, Success},
+			ticks:    []int{3, 3},
+		},
+		{
+			name:     "reactive sequence aborts running child",
+			children: [][]Status{{Success, Failure}, {Running}},
+			build:    func(c []Node) Node { return &Sequence{Children: c, Reactive: true} },
+			want:     []Status{Running, Failure},
+			ticks:    []int{2, 1},
+		},
+		{
+			name:     "selector first success",
+			children: [][]Status{{Failure}, {Success}, {Success}},
+			build:    func(c []Node) Node { return NewSelector(c...) },
+			want:     []Status{Success},
+			ticks:    []int{1, 1, 0},
+		},
+		{
+			name:     "selector all fail",
+			children: [][]Status{{Failure}, {Failure}},
+			build:    func(c []Node) Node { return NewSelector(c...) },
+			want:     []Status{Failure},
+			ticks:    []int{1, 1},
+		},
+		{
+			name:     "selector resumes running child",
+			children: [][]Status{{Failure}, {Running, Failure}, {Success}},
+			build:    func(c []Node) Node { return NewSelector(c...) },
+			want:     []Status{Running, Success},
+			ticks:    []int{1, 2, 1},
+		},
+		{
+			name:     "parallel require all succeeds",
+			children: [][]Status{{Success}, {Running, Success}},
+			build:    func(c []Node) Node { return NewParallel(RequireAll, RequireOne, c...) },
+			want:     []Status{Running, Success},
+			ticks:    []int{1, 2},
+		},
+		{
+			name:     "parallel require one fails",
+			children: [][]Status{{Running, Failure}, {Running}},
+			build:    func(c []Node) Node { return NewParallel(RequireAll, RequireOne, c...) },
+			want:     []Status{Running, Failure},
+			ticks:    []int{2, 2},
+		},
+		{
+			name:     "parallel require one succeeds",
+			children: [][]Status{{Running, Success}, {Running}},
+			build:    func(c []Node) Node { return NewParallel(RequireOne, RequireAll, c...) },
+			want:     []Status{Running, Success},
+			ticks:    []int{2, 2},
+		},
+		{
+			name:     "parallel finished without reaching a policy",
+			children: [][]Status{{Success}, {Failure}},
+			build:    func(c []Node) Node { return NewParallel(RequireAll, RequireAll, c...) },
+			want:     []Status{Failure},
+			ticks:    []int{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		children := make([]*script, len(tt.children))
+		nodes := make([]Node, len(tt.children))
+		for i, statuses := range tt.children {
+			children[i] = newScript(statuses...)
+			nodes[i] = children[i]
+		}
+		got := run(tt.build(nodes), len(tt.want), 0.1)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: statuses %v, want %v", tt.name, got, tt.want)
+		}
+		if counts := ticks(children); !reflect.DeepEqual(counts, tt.ticks) {
+			t.Errorf("%s: child ticks %v, want %v", tt.name, counts, tt.ticks)
+		}
+	}
+}
+
+func TestCompositeReset(t *testing.T) {
+	a, b := newScript(Success), newScript(Running, Success)
+	seq := NewSequence(a, b)
+	run(seq, 1, 0.1)
+	seq.Reset()
+	if a.resets != 1 || b.resets != 1 {
+		t.Fatalf("resets %d, %d, want 1, 1", a.resets, b.resets)
+	}
+	// 重置后从首个子节点重新执行
+	if got := run(seq, 1, 0.1); got[0] != Success || a.ticks != 2 {
+		t.Errorf("after reset: %v with %d ticks of the first child", got, a.ticks)
+	}
+}
+
+func TestDecorators(t *testing.T) {
+	tests := []struct {
+		name  string
+		child []Status
+		build func(child Node) Node
+		delta float64
+		want  []Status
+	}{
+		{"inverter success", []Status{Success}, func(c Node) Node { return NewInverter(c) }, 0.1, []Status{Failure}},
+		{"inverter failure", []Status{Failure}, func(c Node) Node { return NewInverter(c) }, 0.1, []Status{Success}},
+		{"inverter running", []Status{Running}, func(c Node) Node { return NewInverter(c) }, 0.1, []Status{Running}},
+		{"repeat counts successes", []Status{Success}, func(c Node) Node { return NewRepeat(3, c) }, 0.1,
+			[]Status{Running, Running, Success, Running, Running, Success}},
+		{"repeat waits for running child", []Status{Running, Success, Running, Success}, func(c Node) Node { return NewRepeat(2, c) }, 0.1,
+			[]Status{Running, Running, Running, Success}},
+		{"repeat fails with child", []Status{Success, Failure}, func(c Node) Node { return NewRepeat(3, c) }, 0.1,
+			[]Status{Running, Failure}},
+		{"repeat forever", []Status{Success}, func(c Node) Node { return NewRepeat(0, c) }, 0.1,
+			[]Status{Running, Running, Running, Running}},
+		{"cooldown", []Status{Success}, func(c Node) Node { return NewCooldown(0.25, c) }, 0.1,
+			[]Status{Success, Failure, Failure, Success}},
+		{"timeout", []Status{Running}, func(c Node) Node { return NewTimeout(0.25, c) }, 0.1,
+			[]Status{Running, Running, Running, Failure, Running}},
+		{"timeout child finishes", []Status{Running, Success}, func(c Node) Node { return NewTimeout(0.25, c) }, 0.1,
+			[]Status{Running, Success}},
+	}
+	for _, tt := range tests {
+		got := run(tt.build(newScript(tt.child...)), len(tt.want), tt.delta)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: statuses %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecoratorReset(t *testing.T) {
+	child := newScript(Success)
+	r := NewRepeat(3, child)
+	run(r, 2, 0.1)
+	r.Reset()
+	if child.resets != 1 {
+		t.Errorf("repeat reset child %d times, want 1", child.resets)
+	}
+	if got := run(r, 3, 0.1); !reflect.DeepEqual(got, []Status{Running, Running, Success}) {
+		t.Errorf("repeat after reset: %v", got)
+	}
+
+	inv := NewInverter(child)
+	inv.Reset()
+	if child.resets != 2 {
+		t.Errorf("inverter reset child %d times, want 2", child.resets)
+	}
+}
+
+func TestWait(t *testing.T) {
+	w := NewWait(0.5)
+	if got := run(w, 6, 0.2); !reflect.DeepEqual(got, []Status{Running, Running, Success, Running, Running, Success}) {
+		t.Errorf("wait: %v", got)
+	}
+
+	// 重置后重新计时
+	run(w, 2, 0.2)
+	w.Reset()
+	if got := run(w, 3, 0.2); !reflect.DeepEqual(got, []Status{Running, Running, Success}) {
+		t.Errorf("wait after reset: %v", got)
+	}
+}
+
+func TestTree(t *testing.T) {
+	tree := NewTree(NewSequence(NewWait(0.3), NewAction("done", func(ctx *Context) Status {
+		ctx.Blackboard.Set("done", ctx.Time)
+		return Success
+	})))
+	if s := tree.Status(); s != Failure {
+		t.Errorf("initial status %v", s)
+	}
+	for i := 0; i < 2; i++ {
+		if s := tree.Tick(0.2); i == 0 && s != Running || i == 1 && s != Success {
+			t.Fatalf("tick %d: %v", i, s)
+		}
+	}
+	if !tree.Blackboard.Has("done") || tree.Time() != 0.4 {
+		t.Errorf("blackboard %v, time %v", tree.Blackboard.Get("done"), tree.Time())
+	}
+	if (&Tree{}).Tick(0.1) != Failure {
+		t.Error("tree without root should fail")
+	}
+}
+
+func TestParse(t *testing.T) {
+	r := NewRegistry()
+	calls := make([]string, 0)
+	r.Action("attack", func(ctx *Context) Status {
+		calls = append(calls, "attack")
+		return Success
+	})
+	r.Action("patrol", func(ctx *Context) Status {
+		calls = append(calls, "patrol")
+		return Running
+	})
+	r.Condition("near", func(ctx *Context) bool { return ctx.Blackboard.Bool("near") })
+
+	tree, err := r.Parse([]byte(`{
+		"type": "selector",
+		"reactive": true,
+		"children": [
+			{"type": "sequence", "children": [
+				{"type": "condition", "name": "near"},
+				{"type": "cooldown", "duration": 1, "child": {"type": "action", "name": "attack"}}
+			]},
+			{"type": "parallel", "success": "one", "failure": "all", "children": [
+				{"type": "action", "name": "patrol"},
+				{"type": "inverter", "child": {"type": "wait", "duration": 0.5}}
+			]}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tree.Tick(0.1); s != Running {
+		t.Errorf("patrolling: %v", s)
+	}
+	tree.Blackboard.Set("near", true)
+	if s := tree.Tick(0.1); s != Success {
+		t.Errorf("attacking: %v", s)
+	}
+	if want := []string{"patrol", "attack"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %v, want %v", calls, want)
+	}
+
+	root, ok := tree.Root.(*Selector)
+	if !ok || !root.Reactive || len(root.Children) != 2 {
+		t.Fatalf("root %#v", tree.Root)
+	}
+	if p, ok := root.Children[1].(*Parallel); !ok || p.SuccessPolicy != RequireOne || p.FailurePolicy != RequireAll {
+		t.Errorf("parallel %#v", root.Children[1])
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	tree, err := NewRegistry().Parse([]byte(`{"type": "parallel", "children": [{"type": "repeat", "count": 2, "child": {"type": "wait"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := tree.Root.(*Parallel)
+	if p.SuccessPolicy != RequireAll || p.FailurePolicy != RequireOne {
+		t.Errorf("default policies %v, %v", p.SuccessPolicy, p.FailurePolicy)
+	}
+	if r, ok := p.Children[0].(*Repeat); !ok || r.Count != 2 {
+		t.Errorf("repeat %#v", p.Children[0])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		err  string
+	}{
+		{"unknown node type", `{"type": "random"}`, `unknown node type "random"`},
+		{"nested unknown node type", `{"type": "sequence", "children": [{"type": "wait"}, {"type": "loop"}]}`, `unknown node type "loop"`},
+		{"missing type", `{"children": []}`, `unknown node type ""`},
+		{"unknown action", `{"type": "action", "name": "fly"}`, `unknown action "fly"`},
+		{"unknown condition", `{"type": "condition", "name": "wet"}`, `unknown condition "wet"`},
+		{"decorator without child", `{"type": "inverter"}`, `inverter node requires a child`},
+		{"unknown policy", `{"type": "parallel", "success": "most"}`, `unknown parallel policy "most"`},
+		{"invalid json", `{"type": `, `unexpected end of JSON input`},
+	}
+	for _, tt := range tests {
+		tree, err := NewRegistry().Parse([]byte(tt.json))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: error %v, want %q", tt.name, err, tt.err)
+		}
+		if tree != nil {
+			t.Errorf("%s: got a tree with an error", tt.name)
+		}
+	}
+}
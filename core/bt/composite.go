@@ -0,0 +1,198 @@
+package bt
+
+// Sequence, 顺序节点，依次执行子节点，任一子节点失败时失败，全部成功时成功；子节点执行中时下次更新从该子节点继续
+type Sequence struct {
+	Children []Node
+	// 是否为响应式节点，响应式节点每次更新都从首个子节点重新判定，前序子节点失败时中断执行中的子节点
+	Reactive bool
+	index    int
+}
+
+// NewSequence, Sequence 类实例初始化函数
+// 参数:
+//     children: 子节点列表
+// 返回值:
+//     Sequence 类指针
+func NewSequence(children ...Node) *Sequence {
+	return &Sequence{Children: children}
+}
+
+// Tick, Sequence 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (s *Sequence) Tick(ctx *Context) Status {
+	return tickChildren(ctx, s.Children, &s.index, s.Reactive, Success)
+}
+
+// Reset, Sequence 类 Node.Reset() 的实现
+func (s *Sequence) Reset() {
+	s.index = 0
+	resetAll(s.Children)
+}
+
+// Selector, 选择节点，依次执行子节点，任一子节点成功时成功，全部失败时失败；子节点执行中时下次更新从该子节点继续
+type Selector struct {
+	Children []Node
+	// 是否为响应式节点，响应式节点每次更新都从首个子节点重新判定，前序子节点成功时中断执行中的子节点
+	Reactive bool
+	index    int
+}
+
+// NewSelector, Selector 类实例初始化函数
+// 参数:
+//     children: 子节点列表
+// 返回值:
+//     Selector 类指针
+func NewSelector(children ...Node) *Selector {
+	return &Selector{Children: children}
+}
+
+// Tick, Selector 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (s *Selector) Tick(ctx *Context) Status {
+	return tickChildren(ctx, s.Children, &s.index, s.Reactive, Failure)
+}
+
+// Reset, Selector 类 Node.Reset() 的实现
+func (s *Selector) Reset() {
+	s.index = 0
+	resetAll(s.Children)
+}
+
+// Policy, 并行节点的完成策略
+type Policy int
+
+const (
+	// RequireOne, 任一子节点达到该状态即完成
+	RequireOne Policy = iota
+	// RequireAll, 全部子节点达到该状态才完成
+	RequireAll
+)
+
+// Parallel, 并行节点，每次更新执行全部未完成的子节点，按成功与失败策略判定结果，完成时中断仍在执行的子节点；
+// 两种策略同时满足时以失败为准
+type Parallel struct {
+	Children      []Node
+	SuccessPolicy Policy
+	FailurePolicy Policy
+	results       []Status
+}
+
+// NewParallel, Parallel 类实例初始化函数
+// 参数:
+//     success: 成功策略
+//     failure: 失败策略
+//     children: 子节点列表
+// 返回值:
+//     Parallel 类指针
+func NewParallel(success, failure Policy, children ...Node) *Parallel {
+	return &Parallel{Children: children, SuccessPolicy: success, FailurePolicy: failure}
+}
+
+// Tick, Parallel 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (p *Parallel) Tick(ctx *Context) Status {
+	if len(p.results) != len(p.Children) {
+		p.results = make([]Status, len(p.Children))
+	}
+	successes, failures := 0, 0
+	for i, child := range p.Children {
+		if p.results[i] == Running {
+			p.results[i] = child.Tick(ctx)
+		}
+		switch p.results[i] {
+		case Success:
+			successes++
+		case Failure:
+			failures++
+		}
+	}
+
+	status := Running
+	switch {
+	case reached(p.FailurePolicy, failures, len(p.Children)):
+		status = Failure
+	case reached(p.SuccessPolicy, successes, len(p.Children)):
+		status = Success
+	case successes+failures == len(p.Children):
+		// 全部子节点已完成但未满足任何策略
+		status = Failure
+	}
+	if status != Running {
+		p.Reset()
+	}
+	return status
+}
+
+// Reset, Parallel 类 Node.Reset() 的实现
+func (p *Parallel) Reset() {
+	for i := range p.results {
+		p.results[i] = Running
+	}
+	resetAll(p.Children)
+}
+
+// tickChildren, 依次执行顺序节点与选择节点子节点的包内函数
+// 参数:
+//     ctx: Context 类指针
+//     children: 子节点列表
+//     index: 执行中子节点下标指针
+//     reactive: 是否从首个子节点重新判定
+//     next: 子节点返回该状态时继续执行下一子节点，顺序节点为 Success，选择节点为 Failure
+// 返回值:
+//     Status 类，全部子节点返回 next 时为 next
+func tickChildren(ctx *Context, children []Node, index *int, reactive bool, next Status) Status {
+	i := *index
+	if reactive {
+		i = 0
+	}
+	for ; i < len(children); i++ {
+		status := children[i].Tick(ctx)
+		if status == next {
+			continue
+		}
+		// 前序子节点提前决定结果时，中断原先执行中的子节点
+		if *index > i {
+			children[*index].Reset()
+		}
+		if status == Running {
+			*index = i
+			return Running
+		}
+		*index = 0
+		return status
+	}
+	*index = 0
+	return next
+}
+
+// reached, 判断并行节点是否满足完成策略的包内函数
+// 参数:
+//     policy: 完成策略
+//     count: 达到该状态的子节点数
+//     total: 子节点总数
+// 返回值:
+//     bool 类型
+func reached(policy Policy, count, total int) bool {
+	if policy == RequireAll {
+		return total > 0 && count == total
+	}
+	return count > 0
+}
+
+// resetAll, 重置节点列表的包内函数
+// 参数:
+//     nodes: Node 接口对象列表
+func resetAll(nodes []Node) {
+	for _, n := range nodes {
+		n.Reset()
+	}
+}
@@ -0,0 +1,168 @@
+package bt
+
+// Inverter, 取反节点，子节点成功时失败，失败时成功
+type Inverter struct {
+	Child Node
+}
+
+// NewInverter, Inverter 类实例初始化函数
+// 参数:
+//     child: 子节点
+// 返回值:
+//     Inverter 类指针
+func NewInverter(child Node) *Inverter {
+	return &Inverter{Child: child}
+}
+
+// Tick, Inverter 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (i *Inverter) Tick(ctx *Context) Status {
+	switch i.Child.Tick(ctx) {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	}
+	return Running
+}
+
+// Reset, Inverter 类 Node.Reset() 的实现
+func (i *Inverter) Reset() {
+	i.Child.Reset()
+}
+
+// Repeat, 重复节点，子节点成功后重复执行，达到次数时成功，子节点失败时失败
+type Repeat struct {
+	Child Node
+	// 重复次数，不大于 0 时无限重复
+	Count int
+	done  int
+}
+
+// NewRepeat, Repeat 类实例初始化函数
+// 参数:
+//     count: 重复次数，不大于 0 时无限重复
+//     child: 子节点
+// 返回值:
+//     Repeat 类指针
+func NewRepeat(count int, child Node) *Repeat {
+	return &Repeat{Child: child, Count: count}
+}
+
+// Tick, Repeat 类 Node.Tick(ctx *Context) Status 的实现，每次更新最多完成一次子节点，避免单次更新内无限循环
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (r *Repeat) Tick(ctx *Context) Status {
+	switch r.Child.Tick(ctx) {
+	case Running:
+		return Running
+	case Failure:
+		r.done = 0
+		return Failure
+	}
+	r.done++
+	if r.Count > 0 && r.done >= r.Count {
+		r.done = 0
+		return Success
+	}
+	return Running
+}
+
+// Reset, Repeat 类 Node.Reset() 的实现
+func (r *Repeat) Reset() {
+	r.done = 0
+	r.Child.Reset()
+}
+
+// Cooldown, 冷却节点，子节点完成后进入冷却，冷却期间不执行子节点并返回失败
+type Cooldown struct {
+	Child Node
+	// 冷却时长，单位为秒
+	Duration float64
+	readyAt  float64
+}
+
+// NewCooldown, Cooldown 类实例初始化函数
+// 参数:
+//     duration: 冷却时长，单位为秒
+//     child: 子节点
+// 返回值:
+//     Cooldown 类指针
+func NewCooldown(duration float64, child Node) *Cooldown {
+	return &Cooldown{Child: child, Duration: duration}
+}
+
+// Tick, Cooldown 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (c *Cooldown) Tick(ctx *Context) Status {
+	if ctx.Time < c.readyAt {
+		return Failure
+	}
+	status := c.Child.Tick(ctx)
+	if status != Running {
+		c.readyAt = ctx.Time + c.Duration
+	}
+	return status
+}
+
+// Reset, Cooldown 类 Node.Reset() 的实现，冷却计时不受重置影响
+func (c *Cooldown) Reset() {
+	c.Child.Reset()
+}
+
+// Timeout, 超时节点，子节点持续执行超过时限时中断子节点并返回失败
+type Timeout struct {
+	Child Node
+	// 时限，单位为秒
+	Duration float64
+	elapsed  float64
+	running  bool
+}
+
+// NewTimeout, Timeout 类实例初始化函数
+// 参数:
+//     duration: 时限，单位为秒
+//     child: 子节点
+// 返回值:
+//     Timeout 类指针
+func NewTimeout(duration float64, child Node) *Timeout {
+	return &Timeout{Child: child, Duration: duration}
+}
+
+// Tick, Timeout 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (t *Timeout) Tick(ctx *Context) Status {
+	if t.running {
+		t.elapsed += ctx.Delta
+	} else {
+		t.running = true
+		t.elapsed = 0
+	}
+	if t.elapsed >= t.Duration {
+		t.Reset()
+		return Failure
+	}
+	status := t.Child.Tick(ctx)
+	if status != Running {
+		t.running = false
+	}
+	return status
+}
+
+// Reset, Timeout 类 Node.Reset() 的实现
+func (t *Timeout) Reset() {
+	t.running = false
+	t.elapsed = 0
+	t.Child.Reset()
+}
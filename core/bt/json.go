@@ -0,0 +1,192 @@
+package bt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Spec, 行为树节点配置对象，对应 JSON 格式的行为树配置文件
+// 示例:
+//     {
+//         "type": "selector",
+//         "reactive": true,
+//         "children": [
+//             {"type": "sequence", "children": [
+//                 {"type": "condition", "name": "player_near"},
+//                 {"type": "cooldown", "duration": 2, "child": {"type": "action", "name": "shoot"}}
+//             ]},
+//             {"type": "action", "name": "patrol"}
+//         ]
+//     }
+type Spec struct {
+	// 节点类型: sequence, selector, parallel, inverter, repeat, cooldown, timeout, action, condition, wait
+	Type string `json:"type"`
+	// 动作与条件节点在 Registry 中注册的名称
+	Name     string `json:"name,omitempty"`
+	Children []Spec `json:"children,omitempty"`
+	Child    *Spec  `json:"child,omitempty"`
+	// 顺序与选择节点是否为响应式节点
+	Reactive bool `json:"reactive,omitempty"`
+	// 重复节点的次数
+	Count int `json:"count,omitempty"`
+	// 冷却、超时与等待节点的时长，单位为秒
+	Duration float64 `json:"duration,omitempty"`
+	// 并行节点的成功与失败策略: one, all，缺省时成功策略为 all、失败策略为 one
+	Success string `json:"success,omitempty"`
+	Failure string `json:"failure,omitempty"`
+}
+
+// Registry, 叶节点注册表对象，JSON 配置中的动作与条件节点按名称从注册表获取
+type Registry struct {
+	actions    map[string]ActionFunc
+	conditions map[string]ConditionFunc
+}
+
+// NewRegistry, Registry 类实例初始化函数
+// 返回值:
+//     Registry 类指针
+func NewRegistry() *Registry {
+	return &Registry{
+		actions:    make(map[string]ActionFunc),
+		conditions: make(map[string]ConditionFunc),
+	}
+}
+
+// Action, Registry 类注册动作函数的方法，同名动作将被替换
+// 参数:
+//     name: 动作名称
+//     fn: 动作函数
+func (r *Registry) Action(name string, fn ActionFunc) {
+	r.actions[name] = fn
+}
+
+// Condition, Registry 类注册条件函数的方法，同名条件将被替换
+// 参数:
+//     name: 条件名称
+//     fn: 条件函数
+func (r *Registry) Condition(name string, fn ConditionFunc) {
+	r.conditions[name] = fn
+}
+
+// Load, Registry 类从配置文件构建行为树的方法
+// 参数:
+//     file: 配置文件路径
+// 返回值:
+//     Tree 类指针
+//     error 类型，读取、解析或构建失败时返回错误
+func (r *Registry) Load(file string) (*Tree, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return r.Parse(data)
+}
+
+// Parse, Registry 类从 JSON 数据构建行为树的方法
+// 参数:
+//     data: JSON 数据
+// 返回值:
+//     Tree 类指针
+//     error 类型，解析或构建失败时返回错误
+func (r *Registry) Parse(data []byte) (*Tree, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	root, err := r.Build(spec)
+	if err != nil {
+		return nil, err
+	}
+	return NewTree(root), nil
+}
+
+// Build, Registry 类按节点配置构建节点的方法，每次调用均构建新的节点，可为多个实体构建互不影响的行为树
+// 参数:
+//     spec: Spec 类，节点配置
+// 返回值:
+//     Node 接口对象
+//     error 类型，节点类型未知、缺少子节点或叶节点未注册时返回错误
+func (r *Registry) Build(spec Spec) (Node, error) {
+	switch spec.Type {
+	case "sequence", "selector", "parallel":
+		children := make([]Node, 0, len(spec.Children))
+		for _, s := range spec.Children {
+			child, err := r.Build(s)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		switch spec.Type {
+		case "sequence":
+			return &Sequence{Children: children, Reactive: spec.Reactive}, nil
+		case "selector":
+			return &Selector{Children: children, Reactive: spec.Reactive}, nil
+		}
+		success, err := parsePolicy(spec.Success, RequireAll)
+		if err != nil {
+			return nil, err
+		}
+		failure, err := parsePolicy(spec.Failure, RequireOne)
+		if err != nil {
+			return nil, err
+		}
+		return NewParallel(success, failure, children...), nil
+
+	case "inverter", "repeat", "cooldown", "timeout":
+		if spec.Child == nil {
+			return nil, fmt.Errorf("%s node requires a child", spec.Type)
+		}
+		child, err := r.Build(*spec.Child)
+		if err != nil {
+			return nil, err
+		}
+		switch spec.Type {
+		case "inverter":
+			return NewInverter(child), nil
+		case "repeat":
+			return NewRepeat(spec.Count, child), nil
+		case "cooldown":
+			return NewCooldown(spec.Duration, child), nil
+		}
+		return NewTimeout(spec.Duration, child), nil
+
+	case "action":
+		fn, ok := r.actions[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown action %q", spec.Name)
+		}
+		return NewAction(spec.Name, fn), nil
+
+	case "condition":
+		fn, ok := r.conditions[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown condition %q", spec.Name)
+		}
+		return NewCondition(spec.Name, fn), nil
+
+	case "wait":
+		return NewWait(spec.Duration), nil
+	}
+	return nil, fmt.Errorf("unknown node type %q", spec.Type)
+}
+
+// parsePolicy, 解析并行节点策略的包内函数
+// 参数:
+//     name: 策略名称，one 或 all
+//     def: 名称为空时的缺省策略
+// 返回值:
+//     Policy 类
+//     error 类型，名称无法解析时返回错误
+func parsePolicy(name string, def Policy) (Policy, error) {
+	switch name {
+	case "":
+		return def, nil
+	case "one":
+		return RequireOne, nil
+	case "all":
+		return RequireAll, nil
+	}
+	return def, fmt.Errorf("unknown parallel policy %q", name)
+}
@@ -0,0 +1,104 @@
+package bt
+
+// ActionFunc, 动作函数，执行实体行为并返回执行状态
+type ActionFunc func(ctx *Context) Status
+
+// ConditionFunc, 条件函数，判断实体状态
+type ConditionFunc func(ctx *Context) bool
+
+// Action, 动作叶节点
+type Action struct {
+	Name string
+	Fn   ActionFunc
+}
+
+// NewAction, Action 类实例初始化函数
+// 参数:
+//     name: 动作名称，用于调试
+//     fn: 动作函数
+// 返回值:
+//     Action 类指针
+func NewAction(name string, fn ActionFunc) *Action {
+	return &Action{Name: name, Fn: fn}
+}
+
+// Tick, Action 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类，动作函数为 nil 时为 Failure
+func (a *Action) Tick(ctx *Context) Status {
+	if a.Fn == nil {
+		return Failure
+	}
+	return a.Fn(ctx)
+}
+
+// Reset, Action 类 Node.Reset() 的实现
+func (a *Action) Reset() {}
+
+// Condition, 条件叶节点，条件满足时成功，否则失败
+type Condition struct {
+	Name string
+	Fn   ConditionFunc
+}
+
+// NewCondition, Condition 类实例初始化函数
+// 参数:
+//     name: 条件名称，用于调试
+//     fn: 条件函数
+// 返回值:
+//     Condition 类指针
+func NewCondition(name string, fn ConditionFunc) *Condition {
+	return &Condition{Name: name, Fn: fn}
+}
+
+// Tick, Condition 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (c *Condition) Tick(ctx *Context) Status {
+	if c.Fn != nil && c.Fn(ctx) {
+		return Success
+	}
+	return Failure
+}
+
+// Reset, Condition 类 Node.Reset() 的实现
+func (c *Condition) Reset() {}
+
+// Wait, 等待叶节点，执行达到指定时长后成功
+type Wait struct {
+	// 等待时长，单位为秒
+	Duration float64
+	elapsed  float64
+}
+
+// NewWait, Wait 类实例初始化函数
+// 参数:
+//     duration: 等待时长，单位为秒
+// 返回值:
+//     Wait 类指针
+func NewWait(duration float64) *Wait {
+	return &Wait{Duration: duration}
+}
+
+// Tick, Wait 类 Node.Tick(ctx *Context) Status 的实现
+// 参数:
+//     ctx: Context 类指针
+// 返回值:
+//     Status 类
+func (w *Wait) Tick(ctx *Context) Status {
+	w.elapsed += ctx.Delta
+	if w.elapsed >= w.Duration {
+		w.elapsed = 0
+		return Success
+	}
+	return Running
+}
+
+// Reset, Wait 类 Node.Reset() 的实现
+func (w *Wait) Reset() {
+	w.elapsed = 0
+}
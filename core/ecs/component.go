@@ -0,0 +1,299 @@
+package ecs
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ComponentType, 组件类型标识，同一实体同类组件仅能存在一个
+type ComponentType uint8
+
+const (
+	TransformType ComponentType = iota
+	ColliderType
+	SpriteType
+	VelocityType
+	HealthType
+	AIType
+	// UserType, 自定义组件类型的起始值，自定义组件类型应在此基础上递增定义
+	UserType
+)
+
+// Component, 组件接口对象，组件仅承载数据，行为由 System 实现
+type Component interface {
+	ComponentType() ComponentType
+}
+
+// Transform, 变换组件，定义实体的坐标（左上角）与旋转角度
+type Transform struct {
+	X, Y   float32
+	Rotate float32
+}
+
+// ComponentType, Transform 类 Component.ComponentType() ComponentType 的实现
+func (t *Transform) ComponentType() ComponentType {
+	return TransformType
+}
+
+// Collider, 碰撞组件，持有加入 World.Space 的 resolv.Shape 形状对象，以便与既有场景对象互相检测碰撞
+// SolidTags 为实体移动时视作阻挡的形状标签，为空时不做阻挡判定
+type Collider struct {
+	Shape     resolv.Shape
+	SolidTags []string
+}
+
+// ComponentType, Collider 类 Component.ComponentType() ComponentType 的实现
+func (c *Collider) ComponentType() ComponentType {
+	return ColliderType
+}
+
+// Sprite, 精灵组件，定义实体的渲染纹理与尺寸
+type Sprite struct {
+	Texture    *resource.Texture2D
+	W, H       float32
+	Color      mgl32.Vec3
+	IsXReverse bool
+	Hidden     bool
+	// 渲染层序号
+	Layer int
+}
+
+// NewSprite, Sprite 类实例初始化函数
+// 参数:
+//     texture: 渲染纹理
+//     w, h: 渲染尺寸
+// 返回值:
+//     Sprite 类指针
+func NewSprite(texture *resource.Texture2D, w, h float32) *Sprite {
+	return &Sprite{
+		Texture: texture,
+		W:       w,
+		H:       h,
+		Color:   mgl32.Vec3{1, 1, 1},
+	}
+}
+
+// ComponentType, Sprite 类 Component.ComponentType() ComponentType 的实现
+func (s *Sprite) ComponentType() ComponentType {
+	return SpriteType
+}
+
+// Velocity, 速度组件，定义实体水平与垂直方向速度及重力加速度
+type Velocity struct {
+	X, Y    float32
+	Gravity float32
+	MaxSpd  float32
+}
+
+// ComponentType, Velocity 类 Component.ComponentType() ComponentType 的实现
+func (v *Velocity) ComponentType() ComponentType {
+	return VelocityType
+}
+
+// Damage, 伤害事件对象，记录伤害数值、伤害来源与击退矢量
+type Damage struct {
+	Amount int
+	// 伤害来源，如造成伤害的 resolv.Shape 形状对象或 Entity 实体标识
+	Source interface{}
+	// 击退矢量，受伤者速度的增量
+	KnockbackX, KnockbackY float32
+}
+
+// Health, 生命值组件
+type Health struct {
+	HP, MaxHP int
+	// RemoveOnDeath, 生命值归零时是否销毁实体
+	RemoveOnDeath bool
+	// 受伤后的无敌时长，单位为秒，无敌期间不再受到伤害并闪烁渲染
+	IFrames float64
+	// 闪烁间隔，单位为秒
+	BlinkInterval float64
+	// 受伤、死亡与复活回调，均可为 nil
+	OnDamage func(d Damage)
+	OnDeath  func(d Damage)
+	OnRevive func()
+
+	invincible float64
+}
+
+// NewHealth, Health 类实例初始化函数
+// 参数:
+//     maxHP: 最大生命值
+// 返回值:
+//     Health 类指针
+func NewHealth(maxHP int) *Health {
+	return &Health{
+		HP:            maxHP,
+		MaxHP:         maxHP,
+		RemoveOnDeath: true,
+		BlinkInterval: 0.1,
+	}
+}
+
+// IsDead, Health 类判断生命值是否归零的方法
+// 返回值:
+//     bool 类型， true 为已死亡， false 为存活
+func (h *Health) IsDead() bool {
+	return h.HP <= 0
+}
+
+// TakeDamage, Health 类受到伤害的方法，已死亡或处于无敌状态时忽略伤害
+// 参数:
+//     d: Damage 类，伤害事件
+// 返回值:
+//     bool 类型， true 为伤害生效
+func (h *Health) TakeDamage(d Damage) bool {
+	if h.IsDead() || h.Invincible() || d.Amount <= 0 {
+		return false
+	}
+	h.HP -= d.Amount
+	if h.HP < 0 {
+		h.HP = 0
+	}
+	h.invincible = h.IFrames
+	if h.OnDamage != nil {
+		h.OnDamage(d)
+	}
+	if h.IsDead() && h.OnDeath != nil {
+		h.OnDeath(d)
+	}
+	return true
+}
+
+// Heal, Health 类恢复生命值的方法，已死亡时无效
+// 参数:
+//     amount: 恢复量，恢复后不超过最大生命值
+func (h *Health) Heal(amount int) {
+	if h.IsDead() || amount <= 0 {
+		return
+	}
+	h.HP += amount
+	if h.HP > h.MaxHP {
+		h.HP = h.MaxHP
+	}
+}
+
+// Revive, Health 类复活的方法
+// 参数:
+//     hp: 复活后的生命值，不大于 0 时恢复至最大生命值
+func (h *Health) Revive(hp int) {
+	if hp <= 0 || hp > h.MaxHP {
+		hp = h.MaxHP
+	}
+	h.HP = hp
+	h.invincible = 0
+	if h.OnRevive != nil {
+		h.OnRevive()
+	}
+}
+
+// Invincible, Health 类判断是否处于无敌状态的方法
+// 返回值:
+//     bool 类型
+func (h *Health) Invincible() bool {
+	return h.invincible > 0
+}
+
+// SetInvincible, Health 类进入无敌状态的方法，已处于更长的无敌状态时不缩短
+// 参数:
+//     duration: 无敌时长，单位为秒
+func (h *Health) SetInvincible(duration float64) {
+	if duration > h.invincible {
+		h.invincible = duration
+	}
+}
+
+// Visible, Health 类判断无敌闪烁时当前是否应渲染的方法
+// 返回值:
+//     bool 类型，非无敌状态时始终为 true
+func (h *Health) Visible() bool {
+	if !h.Invincible() || h.BlinkInterval <= 0 {
+		return true
+	}
+	return int(h.invincible/h.BlinkInterval)%2 == 1
+}
+
+// Tick, Health 类推进无敌时间的方法
+// 参数:
+//     delta: 与上次更新的时延
+func (h *Health) Tick(delta float64) {
+	if h.invincible > 0 {
+		h.invincible -= delta
+	}
+}
+
+// ComponentType, Health 类 Component.ComponentType() ComponentType 的实现
+func (h *Health) ComponentType() ComponentType {
+	return HealthType
+}
+
+// AI, 行为组件，由 AISystem 每次更新时调用 Think 函数
+type AI struct {
+	Think func(w *World, e Entity, delta float64)
+}
+
+// ComponentType, AI 类 Component.ComponentType() ComponentType 的实现
+func (a *AI) ComponentType() ComponentType {
+	return AIType
+}
+
+// GetTransform, World 类获取实体 Transform 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     Transform 类指针，实体不含该组件时为 nil
+func (w *World) GetTransform(e Entity) *Transform {
+	c, _ := w.Get(e, TransformType).(*Transform)
+	return c
+}
+
+// GetCollider, World 类获取实体 Collider 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     Collider 类指针，实体不含该组件时为 nil
+func (w *World) GetCollider(e Entity) *Collider {
+	c, _ := w.Get(e, ColliderType).(*Collider)
+	return c
+}
+
+// GetSprite, World 类获取实体 Sprite 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     Sprite 类指针，实体不含该组件时为 nil
+func (w *World) GetSprite(e Entity) *Sprite {
+	c, _ := w.Get(e, SpriteType).(*Sprite)
+	return c
+}
+
+// GetVelocity, World 类获取实体 Velocity 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     Velocity 类指针，实体不含该组件时为 nil
+func (w *World) GetVelocity(e Entity) *Velocity {
+	c, _ := w.Get(e, VelocityType).(*Velocity)
+	return c
+}
+
+// GetHealth, World 类获取实体 Health 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     Health 类指针，实体不含该组件时为 nil
+func (w *World) GetHealth(e Entity) *Health {
+	c, _ := w.Get(e, HealthType).(*Health)
+	return c
+}
+
+// GetAI, World 类获取实体 AI 组件的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     AI 类指针，实体不含该组件时为 nil
+func (w *World) GetAI(e Entity) *AI {
+	c, _ := w.Get(e, AIType).(*AI)
+	return c
+}
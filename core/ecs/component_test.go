@@ -0,0 +1,136 @@
+package ecs
+
+import "testing"
+
+func TestHealthTakeDamage(t *testing.T) {
+	tests := []struct {
+		name   string
+		hp     int
+		iframe bool
+		amount int
+		want   bool
+		wantHP int
+	}{
+		{"hit", 3, false, 1, true, 2},
+		{"overkill clamps to zero", 3, false, 5, true, 0},
+		{"ignored during i-frames", 3, true, 1, false, 3},
+		{"ignored when dead", 0, false, 1, false, 0},
+		{"ignored without amount", 3, false, 0, false, 3},
+	}
+	for _, tt := range tests {
+		h := NewHealth(3)
+		h.HP = tt.hp
+		if tt.iframe {
+			h.SetInvincible(1)
+		}
+		damaged, died := 0, 0
+		h.OnDamage = func(d Damage) { damaged++ }
+		h.OnDeath = func(d Damage) { died++ }
+		if got := h.TakeDamage(Damage{Amount: tt.amount}); got != tt.want || h.HP != tt.wantHP {
+			t.Errorf("%s: took %v, hp %d, want %v, %d", tt.name, got, h.HP, tt.want, tt.wantHP)
+		}
+		wantDamaged, wantDied := 0, 0
+		if tt.want {
+			wantDamaged = 1
+			if tt.wantHP == 0 {
+				wantDied = 1
+			}
+		}
+		if damaged != wantDamaged || died != wantDied {
+			t.Errorf("%s: %d damage and %d death callbacks", tt.name, damaged, died)
+		}
+	}
+}
+
+func TestHealthIFrames(t *testing.T) {
+	h := NewHealth(3)
+	h.IFrames = 0.5
+	h.TakeDamage(Damage{Amount: 1})
+	if !h.Invincible() || h.TakeDamage(Damage{Amount: 1}) {
+		t.Fatal("second hit landed during i-frames")
+	}
+	h.Tick(0.25)
+	if h.TakeDamage(Damage{Amount: 1}) {
+		t.Fatal("hit landed before i-frames ended")
+	}
+	h.Tick(0.25)
+	if h.Invincible() || !h.TakeDamage(Damage{Amount: 1}) || h.HP != 1 {
+		t.Errorf("hit after i-frames: hp %d", h.HP)
+	}
+
+	// 更短的无敌时长不会缩短当前无敌状态
+	h.SetInvincible(0.1)
+	h.Tick(0.25)
+	if !h.Invincible() {
+		t.Error("SetInvincible shortened the i-frames")
+	}
+}
+
+func TestHealthHeal(t *testing.T) {
+	tests := []struct {
+		name       string
+		hp, amount int
+		want       int
+	}{
+		{"heal", 1, 1, 2},
+		{"clamped to max", 2, 5, 3},
+		{"negative ignored", 2, -1, 2},
+		{"dead cannot heal", 0, 2, 0},
+	}
+	for _, tt := range tests {
+		h := NewHealth(3)
+		h.HP = tt.hp
+		h.Heal(tt.amount)
+		if h.HP != tt.want {
+			t.Errorf("%s: hp %d, want %d", tt.name, h.HP, tt.want)
+		}
+	}
+}
+
+func TestHealthRevive(t *testing.T) {
+	tests := []struct {
+		name string
+		hp   int
+		want int
+	}{
+		{"partial", 2, 2},
+		{"zero restores max", 0, 3},
+		{"negative restores max", -1, 3},
+		{"above max clamps", 9, 3},
+	}
+	for _, tt := range tests {
+		h := NewHealth(3)
+		h.IFrames = 1
+		h.TakeDamage(Damage{Amount: 3})
+		revived := 0
+		h.OnRevive = func() { revived++ }
+		h.Revive(tt.hp)
+		if h.HP != tt.want || h.IsDead() || h.Invincible() || revived != 1 {
+			t.Errorf("%s: hp %d, invincible %v, %d callbacks", tt.name, h.HP, h.Invincible(), revived)
+		}
+	}
+}
+
+func TestHealthVisible(t *testing.T) {
+	h := NewHealth(3)
+	h.IFrames = 1
+	h.BlinkInterval = 0.25
+	if !h.Visible() {
+		t.Fatal("hidden without i-frames")
+	}
+	h.TakeDamage(Damage{Amount: 1})
+	// 无敌剩余 1、0.75、0.5、0.25 秒时交替隐藏与显示，结束后始终显示
+	want := []bool{false, true, false, true, true}
+	for i, visible := range want {
+		if h.Visible() != visible {
+			t.Errorf("step %d: visible %v, want %v", i, h.Visible(), visible)
+		}
+		h.Tick(0.25)
+	}
+
+	h.BlinkInterval = 0
+	h.SetInvincible(1)
+	if !h.Visible() {
+		t.Error("blink interval 0 should never hide")
+	}
+}
@@ -0,0 +1,189 @@
+package ecs
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// 内置系统的默认优先级，数值越小越先执行
+const (
+	AIPriority      = 100
+	PhysicsPriority = 200
+	HealthPriority  = 300
+	SpritePriority  = 400
+)
+
+// System, 系统接口对象，定义系统的执行优先级与更新方法
+type System interface {
+	Priority() int
+	Update(w *World, delta float64)
+}
+
+// Drawer, 渲染系统接口对象，实现该接口的系统会在 World.Draw 时被调用
+type Drawer interface {
+	Draw(w *World, renderer *render.SpriteRenderer)
+}
+
+// AISystem, 行为系统，调用实体 AI 组件的 Think 函数
+type AISystem struct{}
+
+// Priority, AISystem 类 System.Priority() int 的实现
+func (s *AISystem) Priority() int {
+	return AIPriority
+}
+
+// Update, AISystem 类 System.Update(w *World, delta float64) 的实现
+// 参数:
+//     w: World 类指针
+//     delta: 与上次更新的时延
+func (s *AISystem) Update(w *World, delta float64) {
+	for _, e := range w.Query(AIType) {
+		if ai := w.GetAI(e); ai.Think != nil {
+			ai.Think(w, e, delta)
+		}
+	}
+}
+
+// PhysicsSystem, 物理系统，根据 Velocity 组件移动实体，含 Collider 组件的实体会在 World.Space 中按 SolidTags 做阻挡判定
+type PhysicsSystem struct{}
+
+// Priority, PhysicsSystem 类 System.Priority() int 的实现
+func (s *PhysicsSystem) Priority() int {
+	return PhysicsPriority
+}
+
+// Update, PhysicsSystem 类 System.Update(w *World, delta float64) 的实现
+// 参数:
+//     w: World 类指针
+//     delta: 与上次更新的时延
+func (s *PhysicsSystem) Update(w *World, delta float64) {
+	for _, e := range w.Query(TransformType, VelocityType) {
+		t := w.GetTransform(e)
+		v := w.GetVelocity(e)
+
+		v.Y += v.Gravity
+		if v.MaxSpd > 0 {
+			if v.X > v.MaxSpd {
+				v.X = v.MaxSpd
+			} else if v.X < -v.MaxSpd {
+				v.X = -v.MaxSpd
+			}
+		}
+
+		x := int32(v.X)
+		y := int32(v.Y)
+
+		c := w.GetCollider(e)
+		if c == nil || c.Shape == nil {
+			t.X += float32(x)
+			t.Y += float32(y)
+			continue
+		}
+
+		c.Shape.SetXY(int32(t.X), int32(t.Y))
+		if len(c.SolidTags) > 0 {
+			solids := w.Space.Filter(func(shape resolv.Shape) bool {
+				return shape != c.Shape && hasAnyTag(shape, c.SolidTags)
+			})
+
+			if res := solids.Resolve(c.Shape, x, 0); res.Colliding() {
+				x = res.ResolveX
+				v.X = 0
+			}
+			c.Shape.Move(x, 0)
+
+			if res := solids.Resolve(c.Shape, 0, y); res.Colliding() {
+				y = res.ResolveY
+				v.Y = 0
+			}
+			c.Shape.Move(0, y)
+		} else {
+			c.Shape.Move(x, y)
+		}
+
+		t.X += float32(x)
+		t.Y += float32(y)
+	}
+}
+
+// HealthSystem, 生命值系统，推进无敌时间，并销毁生命值归零且 RemoveOnDeath 为 true 的实体
+type HealthSystem struct{}
+
+// Priority, HealthSystem 类 System.Priority() int 的实现
+func (s *HealthSystem) Priority() int {
+	return HealthPriority
+}
+
+// Update, HealthSystem 类 System.Update(w *World, delta float64) 的实现
+// 参数:
+//     w: World 类指针
+//     delta: 与上次更新的时延
+func (s *HealthSystem) Update(w *World, delta float64) {
+	for _, e := range w.Query(HealthType) {
+		h := w.GetHealth(e)
+		h.Tick(delta)
+		if h.IsDead() && h.RemoveOnDeath {
+			w.Destroy(e)
+		}
+	}
+}
+
+// SpriteSystem, 精灵渲染系统，按 Transform 组件渲染含 Sprite 组件的实体
+type SpriteSystem struct{}
+
+// Priority, SpriteSystem 类 System.Priority() int 的实现
+func (s *SpriteSystem) Priority() int {
+	return SpritePriority
+}
+
+// Update, SpriteSystem 类 System.Update(w *World, delta float64) 的实现，渲染系统无需更新
+func (s *SpriteSystem) Update(w *World, delta float64) {}
+
+// Draw, SpriteSystem 类 Drawer.Draw(w *World, renderer *render.SpriteRenderer) 的实现
+// 参数:
+//     w: World 类指针
+//     renderer: render.SpriteRenderer 类指针，指定渲染器
+func (s *SpriteSystem) Draw(w *World, renderer *render.SpriteRenderer) {
+	for _, e := range w.Query(TransformType, SpriteType) {
+		t := w.GetTransform(e)
+		sp := w.GetSprite(e)
+		if sp.Hidden || sp.Texture == nil || !w.LayerMask.Has(sp.Layer) {
+			continue
+		}
+		// 无敌闪烁
+		if h := w.GetHealth(e); h != nil && !h.Visible() {
+			continue
+		}
+		position := &mgl32.Vec2{t.X, t.Y}
+		size := &mgl32.Vec2{sp.W, sp.H}
+		renderer.DrawSprite(sp.Texture, position, size, t.Rotate, &sp.Color, sp.IsXReverse)
+	}
+}
+
+// NewDefaultSystems, 内置系统集合初始化函数
+// 返回值:
+//     System 接口对象切片，包含 AISystem、PhysicsSystem、HealthSystem、SpriteSystem
+func NewDefaultSystems() []System {
+	return []System{
+		&AISystem{},
+		&PhysicsSystem{},
+		&HealthSystem{},
+		&SpriteSystem{},
+	}
+}
+
+// hasAnyTag, 判断形状对象是否含有标签列表中任一标签的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+//     tags: 标签列表
+// 返回值:
+//     bool 类型， true 为含有， false 为不含有
+func hasAnyTag(shape resolv.Shape, tags []string) bool {
+	for _, tag := range tags {
+		if shape.HasTags(tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+// ecs 包，该包在 resolv 形状对象之上提供实体-组件-系统（Entity-Component-System）层，
+// 使新的敌人、道具等游戏对象可以通过组件数据组合定义，而无需新增结构体嵌套或在 Scene 中特殊处理
+package ecs
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"sort"
+)
+
+// Entity, 实体标识，仅作为组件集合的索引，本身不含任何数据
+type Entity uint32
+
+// NoEntity, 空实体标识，不会被 World 分配
+const NoEntity Entity = 0
+
+// World, 实体世界对象，管理实体、组件与系统，并与 resolv.Space 共享碰撞形状
+type World struct {
+	Space      *resolv.Space
+	nextID     Entity
+	entities   []Entity
+	components map[ComponentType]map[Entity]Component
+	shapes     map[resolv.Shape]Entity
+	systems    []System
+	destroyed  []Entity
+	updating   bool
+	// 渲染层掩码，渲染系统仅渲染该掩码包含的渲染层
+	LayerMask render.LayerMask
+}
+
+// NewWorld, World 类实例初始化函数
+// 参数:
+//     sp: resolv.Space 类指针，碰撞组件的形状对象将被加入该空间，为 nil 时新建空间
+// 返回值:
+//     World 类指针
+func NewWorld(sp *resolv.Space) *World {
+	if sp == nil {
+		sp = resolv.NewSpace()
+	}
+	return &World{
+		Space:      sp,
+		nextID:     NoEntity,
+		entities:   make([]Entity, 0),
+		components: make(map[ComponentType]map[Entity]Component),
+		shapes:     make(map[resolv.Shape]Entity),
+		systems:    make([]System, 0),
+		destroyed:  make([]Entity, 0),
+		LayerMask:  render.AllLayers,
+	}
+}
+
+// NewEntity, World 类创建实体的方法
+// 参数:
+//     components: Component 接口对象列表，实体初始组件
+// 返回值:
+//     Entity 类型，新实体标识
+func (w *World) NewEntity(components ...Component) Entity {
+	w.nextID++
+	e := w.nextID
+	w.entities = append(w.entities, e)
+	w.Add(e, components...)
+	return e
+}
+
+// Add, World 类为实体添加组件的方法，同类组件将被替换
+// 参数:
+//     e: 实体标识
+//     components: Component 接口对象列表
+func (w *World) Add(e Entity, components ...Component) {
+	if !w.Alive(e) {
+		return
+	}
+	for _, c := range components {
+		t := c.ComponentType()
+		if w.components[t] == nil {
+			w.components[t] = make(map[Entity]Component)
+		}
+		if old, ok := w.components[t][e]; ok {
+			w.detach(e, old)
+		}
+		w.components[t][e] = c
+		w.attach(e, c)
+	}
+}
+
+// Remove, World 类移除实体指定类型组件的方法
+// 参数:
+//     e: 实体标识
+//     types: ComponentType 类型列表，需移除的组件类型
+func (w *World) Remove(e Entity, types ...ComponentType) {
+	for _, t := range types {
+		if c, ok := w.components[t][e]; ok {
+			w.detach(e, c)
+			delete(w.components[t], e)
+		}
+	}
+}
+
+// Get, World 类获取实体指定类型组件的方法
+// 参数:
+//     e: 实体标识
+//     t: 组件类型
+// 返回值:
+//     Component 接口对象，实体不含该组件时为 nil
+func (w *World) Get(e Entity, t ComponentType) Component {
+	return w.components[t][e]
+}
+
+// Has, World 类判断实体是否包含全部指定类型组件的方法
+// 参数:
+//     e: 实体标识
+//     types: ComponentType 类型列表
+// 返回值:
+//     bool 类型， true 为全部包含， false 为存在缺失
+func (w *World) Has(e Entity, types ...ComponentType) bool {
+	for _, t := range types {
+		if _, ok := w.components[t][e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Alive, World 类判断实体是否存在的方法
+// 参数:
+//     e: 实体标识
+// 返回值:
+//     bool 类型， true 为存在， false 为不存在或已销毁
+func (w *World) Alive(e Entity) bool {
+	i := sort.Search(len(w.entities), func(i int) bool { return w.entities[i] >= e })
+	return i < len(w.entities) && w.entities[i] == e
+}
+
+// Destroy, World 类销毁实体的方法，系统更新期间调用时延迟至本轮更新结束后执行
+// 参数:
+//     e: 实体标识
+func (w *World) Destroy(e Entity) {
+	if w.updating {
+		w.destroyed = append(w.destroyed, e)
+		return
+	}
+	w.destroy(e)
+}
+
+// Entities, World 类获取全部实体的方法
+// 返回值:
+//     Entity 类型切片，按创建顺序排列
+func (w *World) Entities() []Entity {
+	entities := make([]Entity, len(w.entities))
+	copy(entities, w.entities)
+	return entities
+}
+
+// Query, World 类查询包含全部指定类型组件的实体的方法
+// 参数:
+//     types: ComponentType 类型列表
+// 返回值:
+//     Entity 类型切片，按创建顺序排列，保证遍历顺序的确定性
+func (w *World) Query(types ...ComponentType) []Entity {
+	result := make([]Entity, 0)
+	for _, e := range w.entities {
+		if w.Has(e, types...) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// EntityOf, World 类根据碰撞形状查找所属实体的方法
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     Entity 类型，所属实体标识
+//     bool 类型， true 为该形状属于本世界中的实体
+func (w *World) EntityOf(shape resolv.Shape) (Entity, bool) {
+	e, ok := w.shapes[shape]
+	return e, ok
+}
+
+// AddSystem, World 类注册系统的方法，系统按优先级升序执行，优先级相同时按注册顺序执行
+// 参数:
+//     systems: System 接口对象列表
+func (w *World) AddSystem(systems ...System) {
+	w.systems = append(w.systems, systems...)
+	sort.SliceStable(w.systems, func(i, j int) bool {
+		return w.systems[i].Priority() < w.systems[j].Priority()
+	})
+}
+
+// Update, World 类更新全部系统的方法
+// 参数:
+//     delta: 与上次更新的时延
+func (w *World) Update(delta float64) {
+	w.updating = true
+	for _, s := range w.systems {
+		s.Update(w, delta)
+	}
+	w.updating = false
+
+	for _, e := range w.destroyed {
+		w.destroy(e)
+	}
+	w.destroyed = w.destroyed[:0]
+}
+
+// Draw, World 类渲染方法，依次调用实现了 Drawer 接口的系统
+// 参数:
+//     renderer: render.SpriteRenderer 类指针，指定渲染器
+func (w *World) Draw(renderer *render.SpriteRenderer) {
+	for _, s := range w.systems {
+		if d, ok := s.(Drawer); ok {
+			d.Draw(w, renderer)
+		}
+	}
+}
+
+// Clear, World 类清空全部实体的方法，已注册的系统将被保留
+func (w *World) Clear() {
+	for _, e := range w.Entities() {
+		w.destroy(e)
+	}
+	w.destroyed = w.destroyed[:0]
+}
+
+// Damage, World 类对实体造成伤害的方法，伤害生效时按击退矢量增加实体速度
+// 参数:
+//     e: 实体标识
+//     d: Damage 类，伤害事件
+// 返回值:
+//     bool 类型，实体不含 Health 组件或伤害未生效时为 false
+func (w *World) Damage(e Entity, d Damage) bool {
+	h := w.GetHealth(e)
+	if h == nil || !h.TakeDamage(d) {
+		return false
+	}
+	if v := w.GetVelocity(e); v != nil {
+		v.X += d.KnockbackX
+		v.Y += d.KnockbackY
+	}
+	return true
+}
+
+// destroy, World 类立即销毁实体的包内方法
+// 参数:
+//     e: 实体标识
+func (w *World) destroy(e Entity) {
+	i := sort.Search(len(w.entities), func(i int) bool { return w.entities[i] >= e })
+	if i >= len(w.entities) || w.entities[i] != e {
+		return
+	}
+	for t := range w.components {
+		w.Remove(e, t)
+	}
+	w.entities = append(w.entities[:i], w.entities[i+1:]...)
+}
+
+// attach, World 类组件加入实体后处理与 resolv.Space 互通的包内方法
+// 参数:
+//     e: 实体标识
+//     c: Component 接口对象
+func (w *World) attach(e Entity, c Component) {
+	if collider, ok := c.(*Collider); ok && collider.Shape != nil {
+		w.shapes[collider.Shape] = e
+		if !w.Space.Contains(collider.Shape) {
+			w.Space.Add(collider.Shape)
+		}
+	}
+}
+
+// detach, World 类组件移出实体后处理与 resolv.Space 互通的包内方法
+// 参数:
+//     e: 实体标识
+//     c: Component 接口对象
+func (w *World) detach(e Entity, c Component) {
+	if collider, ok := c.(*Collider); ok && collider.Shape != nil {
+		delete(w.shapes, collider.Shape)
+		w.Space.Remove(collider.Shape)
+	}
+}
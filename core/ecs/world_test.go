@@ -0,0 +1,171 @@
+package ecs
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"reflect"
+	"testing"
+)
+
+// recorder, 记录执行次序的测试系统
+type recorder struct {
+	name     string
+	priority int
+	log      *[]string
+}
+
+func (r *recorder) Priority() int {
+	return r.priority
+}
+
+func (r *recorder) Update(w *World, delta float64) {
+	*r.log = append(*r.log, r.name)
+}
+
+func TestSystemOrder(t *testing.T) {
+	var log []string
+	w := NewWorld(nil)
+	w.AddSystem(
+		&recorder{"physics", PhysicsPriority, &log},
+		&recorder{"late", SpritePriority + 1, &log},
+		&recorder{"ai", AIPriority, &log},
+	)
+	w.AddSystem(
+		&recorder{"physics 2", PhysicsPriority, &log},
+		&recorder{"early", 0, &log},
+	)
+	for i := 0; i < 3; i++ {
+		log = log[:0]
+		w.Update(0.1)
+		want := []string{"early", "ai", "physics", "physics 2", "late"}
+		if !reflect.DeepEqual(log, want) {
+			t.Fatalf("update %d ran %v, want %v", i, log, want)
+		}
+	}
+}
+
+func TestComponents(t *testing.T) {
+	w := NewWorld(nil)
+	e := w.NewEntity(&Transform{X: 1}, &Velocity{X: 2})
+	if !w.Alive(e) || !w.Has(e, TransformType, VelocityType) || w.Has(e, HealthType) {
+		t.Fatalf("entity %d components wrong", e)
+	}
+	if tr := w.GetTransform(e); tr == nil || tr.X != 1 {
+		t.Errorf("transform %+v", tr)
+	}
+	if w.GetHealth(e) != nil {
+		t.Error("missing component should be nil")
+	}
+
+	// 同类组件被替换
+	w.Add(e, &Transform{X: 5})
+	if w.GetTransform(e).X != 5 {
+		t.Errorf("transform not replaced: %+v", w.GetTransform(e))
+	}
+
+	w.Remove(e, VelocityType)
+	if w.Has(e, VelocityType) || w.GetVelocity(e) != nil {
+		t.Error("velocity not removed")
+	}
+	if got := w.Query(TransformType); !reflect.DeepEqual(got, []Entity{e}) {
+		t.Errorf("query %v", got)
+	}
+
+	// 已销毁实体不能添加组件
+	w.Destroy(e)
+	w.Add(e, &Velocity{})
+	if w.Alive(e) || w.Has(e, TransformType) || w.Has(e, VelocityType) {
+		t.Error("destroyed entity still has components")
+	}
+}
+
+func TestQueryOrder(t *testing.T) {
+	w := NewWorld(nil)
+	var want []Entity
+	for i := 0; i < 5; i++ {
+		e := w.NewEntity(&Transform{})
+		if i%2 == 0 {
+			w.Add(e, &Velocity{})
+			want = append(want, e)
+		}
+	}
+	if got := w.Query(TransformType, VelocityType); !reflect.DeepEqual(got, want) {
+		t.Errorf("query %v, want %v", got, want)
+	}
+}
+
+func TestColliderSharesSpace(t *testing.T) {
+	sp := resolv.NewSpace()
+	w := NewWorld(sp)
+	shape := resolv.NewRectangle(0, 0, 10, 10, 0, 1, nil, nil)
+	e := w.NewEntity(&Collider{Shape: shape})
+	if !sp.Contains(shape) {
+		t.Fatal("collider shape not added to the space")
+	}
+	if got, ok := w.EntityOf(shape); !ok || got != e {
+		t.Errorf("EntityOf %d, %v", got, ok)
+	}
+	w.Remove(e, ColliderType)
+	if sp.Contains(shape) {
+		t.Error("collider shape still in the space after removal")
+	}
+	if _, ok := w.EntityOf(shape); ok {
+		t.Error("shape still maps to the entity")
+	}
+}
+
+func TestClear(t *testing.T) {
+	var log []string
+	sp := resolv.NewSpace()
+	w := NewWorld(sp)
+	w.AddSystem(&recorder{"system", 0, &log})
+	shape := resolv.NewRectangle(0, 0, 10, 10, 0, 1, nil, nil)
+	a := w.NewEntity(&Collider{Shape: shape}, &Transform{})
+	b := w.NewEntity(&Health{HP: 1, MaxHP: 1})
+	w.Clear()
+	if w.Alive(a) || w.Alive(b) || len(w.Entities()) != 0 || len(w.Query(TransformType)) != 0 {
+		t.Error("entities survived Clear")
+	}
+	if sp.Contains(shape) {
+		t.Error("collider shape survived Clear")
+	}
+	w.Update(0.1)
+	if len(log) != 1 {
+		t.Error("systems were not kept after Clear")
+	}
+	if c := w.NewEntity(); c == a || c == b {
+		t.Errorf("entity id %d reused after Clear", c)
+	}
+}
+
+func TestDestroyDuringUpdate(t *testing.T) {
+	w := NewWorld(nil)
+	var seen []Entity
+	e := w.NewEntity(&Health{HP: 0, MaxHP: 1, RemoveOnDeath: true}, &AI{Think: func(w *World, e Entity, delta float64) {
+		seen = append(seen, e)
+	}})
+	other := w.NewEntity(&AI{Think: func(w *World, e Entity, delta float64) {
+		seen = append(seen, e)
+	}})
+	w.AddSystem(NewDefaultSystems()...)
+	w.Update(0.1)
+	if w.Alive(e) || !w.Alive(other) {
+		t.Errorf("dead entity alive %v, other alive %v", w.Alive(e), w.Alive(other))
+	}
+	if !reflect.DeepEqual(seen, []Entity{e, other}) {
+		t.Errorf("AI ran for %v", seen)
+	}
+}
+
+func TestDamage(t *testing.T) {
+	w := NewWorld(nil)
+	e := w.NewEntity(NewHealth(3), &Velocity{})
+	if !w.Damage(e, Damage{Amount: 1, KnockbackX: 2, KnockbackY: -1}) {
+		t.Fatal("damage ignored")
+	}
+	if v := w.GetVelocity(e); v.X != 2 || v.Y != -1 {
+		t.Errorf("knockback %+v", v)
+	}
+	if w.Damage(w.NewEntity(), Damage{Amount: 1}) {
+		t.Error("entity without health took damage")
+	}
+}
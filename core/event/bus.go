@@ -0,0 +1,205 @@
+// event 包，该包提供事件总线：以事件类型订阅与发布事件，支持立即派发与排队至指定时机统一派发，
+// 订阅者按优先级依次处理事件并可随时取消订阅，使武器、伤害、拾取、音频与界面等系统无需相互引用即可协作
+package event
+
+import "sort"
+
+// Type, 事件类型名称
+type Type string
+
+// AnyType, 订阅通配类型，以其订阅的处理函数接收全部类型的事件
+const AnyType Type = "*"
+
+// Event, 事件接口对象
+type Event interface {
+	// EventType, 获取事件类型名称
+	EventType() Type
+}
+
+// Handler, 事件处理函数
+type Handler func(e Event)
+
+// Subscription, 订阅对象，用于取消订阅
+type Subscription struct {
+	bus      *Bus
+	typ      Type
+	priority int
+	seq      int
+	handler  Handler
+	active   bool
+}
+
+// Unsubscribe, Subscription 类取消订阅的方法，派发过程中取消时，本次派发中尚未处理的订阅者不再收到事件，重复调用无效
+func (s *Subscription) Unsubscribe() {
+	if s == nil || !s.active {
+		return
+	}
+	s.active = false
+	s.bus.remove(s)
+}
+
+// Active, Subscription 类判断订阅是否有效的方法
+// 返回值:
+//     bool 类型
+func (s *Subscription) Active() bool {
+	return s != nil && s.active
+}
+
+// Bus, 事件总线对象，非并发安全，应在逻辑更新所在的协程中使用
+type Bus struct {
+	subs  map[Type][]*Subscription
+	queue []Event
+	seq   int
+}
+
+// NewBus, Bus 类实例初始化函数
+// 返回值:
+//     Bus 类指针
+func NewBus() *Bus {
+	return &Bus{
+		subs:  make(map[Type][]*Subscription),
+		queue: make([]Event, 0),
+	}
+}
+
+// Subscribe, Bus 类以默认优先级 0 订阅事件的方法
+// 参数:
+//     typ: 事件类型名称，为 AnyType 时接收全部类型的事件
+//     handler: 事件处理函数
+// 返回值:
+//     Subscription 类指针
+func (b *Bus) Subscribe(typ Type, handler Handler) *Subscription {
+	return b.SubscribePriority(typ, 0, handler)
+}
+
+// SubscribePriority, Bus 类按优先级订阅事件的方法，优先级高的订阅者先处理事件，优先级相同时先订阅者先处理
+// 参数:
+//     typ: 事件类型名称，为 AnyType 时接收全部类型的事件
+//     priority: 优先级
+//     handler: 事件处理函数
+// 返回值:
+//     Subscription 类指针
+func (b *Bus) SubscribePriority(typ Type, priority int, handler Handler) *Subscription {
+	b.seq++
+	s := &Subscription{bus: b, typ: typ, priority: priority, seq: b.seq, handler: handler, active: true}
+	// 复制订阅列表，避免影响派发中的遍历
+	list := make([]*Subscription, len(b.subs[typ]), len(b.subs[typ])+1)
+	copy(list, b.subs[typ])
+	list = append(list, s)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].priority > list[j].priority
+	})
+	b.subs[typ] = list
+	return s
+}
+
+// Publish, Bus 类立即派发事件的方法，处理函数返回后本方法才返回
+// 参数:
+//     e: Event 接口对象，为 nil 时忽略
+func (b *Bus) Publish(e Event) {
+	if e == nil {
+		return
+	}
+	b.dispatch(e, b.handlers(e.EventType()))
+}
+
+// Enqueue, Bus 类将事件加入队列的方法，事件在下次调用 Flush 时派发
+// 参数:
+//     e: Event 接口对象，为 nil 时忽略
+func (b *Bus) Enqueue(e Event) {
+	if e == nil {
+		return
+	}
+	b.queue = append(b.queue, e)
+}
+
+// Flush, Bus 类按加入顺序派发队列中事件的方法，派发过程中新加入队列的事件留待下次调用时派发
+// 返回值:
+//     int 类型，本次派发的事件数
+func (b *Bus) Flush() int {
+	queue := b.queue
+	if len(queue) == 0 {
+		return 0
+	}
+	b.queue = make([]Event, 0, len(queue))
+	for _, e := range queue {
+		b.Publish(e)
+	}
+	return len(queue)
+}
+
+// Pending, Bus 类获取队列中待派发事件数的方法
+// 返回值:
+//     int 类型
+func (b *Bus) Pending() int {
+	return len(b.queue)
+}
+
+// Clear, Bus 类清空全部订阅与队列中事件的方法
+func (b *Bus) Clear() {
+	for _, list := range b.subs {
+		for _, s := range list {
+			s.active = false
+		}
+	}
+	b.subs = make(map[Type][]*Subscription)
+	b.queue = make([]Event, 0)
+}
+
+// handlers, Bus 类获取事件类型全部订阅者的包内方法，通配订阅者与该类型订阅者按优先级合并
+// 参数:
+//     typ: 事件类型名称
+// 返回值:
+//     Subscription 类指针列表
+func (b *Bus) handlers(typ Type) []*Subscription {
+	list, all := b.subs[typ], b.subs[AnyType]
+	if typ == AnyType || len(all) == 0 {
+		return list
+	}
+	if len(list) == 0 {
+		return all
+	}
+	merged := make([]*Subscription, 0, len(list)+len(all))
+	merged = append(merged, list...)
+	merged = append(merged, all...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].priority != merged[j].priority {
+			return merged[i].priority > merged[j].priority
+		}
+		return merged[i].seq < merged[j].seq
+	})
+	return merged
+}
+
+// dispatch, Bus 类将事件依次交由订阅者处理的包内方法，跳过已取消的订阅
+// 参数:
+//     e: Event 接口对象
+//     subs: Subscription 类指针列表
+func (b *Bus) dispatch(e Event, subs []*Subscription) {
+	for _, s := range subs {
+		if s.active {
+			s.handler(e)
+		}
+	}
+}
+
+// remove, Bus 类移除订阅的包内方法
+// 参数:
+//     s: Subscription 类指针
+func (b *Bus) remove(s *Subscription) {
+	list := b.subs[s.typ]
+	for i, sub := range list {
+		if sub == s {
+			// 复制订阅列表，避免影响派发中的遍历
+			next := make([]*Subscription, 0, len(list)-1)
+			next = append(next, list[:i]...)
+			next = append(next, list[i+1:]...)
+			if len(next) == 0 {
+				delete(b.subs, s.typ)
+			} else {
+				b.subs[s.typ] = next
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testEvent Type
+
+func (e testEvent) EventType() Type { return Type(e) }
+
+func TestPriorityOrder(t *testing.T) {
+	b := NewBus()
+	var got []string
+	record := func(name string) Handler {
+		return func(e Event) { got = append(got, name) }
+	}
+	b.Subscribe("hit", record("a0"))
+	b.SubscribePriority("hit", 10, record("b10"))
+	b.SubscribePriority("hit", -1, record("c-1"))
+	b.Subscribe("hit", record("d0"))
+	b.SubscribePriority("hit", 10, record("e10"))
+
+	b.Publish(testEvent("hit"))
+	want := []string{"b10", "e10", "a0", "d0", "c-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAnyTypeMerging(t *testing.T) {
+	b := NewBus()
+	var got []string
+	record := func(name string) Handler {
+		return func(e Event) { got = append(got, name+":"+string(e.EventType())) }
+	}
+	b.Subscribe("hit", record("hit0"))
+	b.Subscribe(AnyType, record("any0"))
+	b.SubscribePriority(AnyType, 5, record("any5"))
+	b.SubscribePriority("hit", 5, record("hit5"))
+
+	tests := []struct {
+		typ  Type
+		want []string
+	}{
+		{"hit", []string{"any5:hit", "hit5:hit", "hit0:hit", "any0:hit"}},
+		{"miss", []string{"any5:miss", "any0:miss"}},
+	}
+	for _, tt := range tests {
+		got = nil
+		b.Publish(testEvent(tt.typ))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("publish %q: got %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestUnsubscribeDuringDispatch(t *testing.T) {
+	b := NewBus()
+	var got []string
+	var later, any *Subscription
+	b.SubscribePriority("hit", 1, func(e Event) {
+		got = append(got, "first")
+		later.Unsubscribe()
+		any.Unsubscribe()
+	})
+	later = b.Subscribe("hit", func(e Event) { got = append(got, "later") })
+	any = b.Subscribe(AnyType, func(e Event) { got = append(got, "any") })
+
+	b.Publish(testEvent("hit"))
+	if !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("got %v, want only the first handler", got)
+	}
+	if later.Active() || any.Active() {
+		t.Errorf("unsubscribed handlers still active")
+	}
+	later.Unsubscribe()
+
+	got = nil
+	b.Publish(testEvent("hit"))
+	if !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("second publish: got %v", got)
+	}
+}
+
+func TestFlushDefersEnqueued(t *testing.T) {
+	b := NewBus()
+	var got []Type
+	b.Subscribe(AnyType, func(e Event) {
+		got = append(got, e.EventType())
+		if e.EventType() == "a" {
+			b.Enqueue(testEvent("c"))
+		}
+	})
+	b.Enqueue(testEvent("a"))
+	b.Enqueue(nil)
+	b.Enqueue(testEvent("b"))
+	if b.Pending() != 2 {
+		t.Fatalf("pending %d, want 2", b.Pending())
+	}
+
+	if n := b.Flush(); n != 2 || !reflect.DeepEqual(got, []Type{"a", "b"}) {
+		t.Errorf("first flush: %d events, got %v", n, got)
+	}
+	if b.Pending() != 1 {
+		t.Errorf("pending after first flush %d, want 1", b.Pending())
+	}
+	if n := b.Flush(); n != 1 || !reflect.DeepEqual(got, []Type{"a", "b", "c"}) {
+		t.Errorf("second flush: %d events, got %v", n, got)
+	}
+	if n := b.Flush(); n != 0 {
+		t.Errorf("empty flush dispatched %d events", n)
+	}
+}
+
+func TestClearDeactivates(t *testing.T) {
+	b := NewBus()
+	calls := 0
+	sub := b.Subscribe("hit", func(e Event) { calls++ })
+	any := b.Subscribe(AnyType, func(e Event) { calls++ })
+	b.Enqueue(testEvent("hit"))
+
+	b.Clear()
+	if sub.Active() || any.Active() {
+		t.Errorf("subscriptions still active after clear")
+	}
+	if b.Pending() != 0 {
+		t.Errorf("pending %d after clear", b.Pending())
+	}
+	b.Publish(testEvent("hit"))
+	b.Flush()
+	if calls != 0 {
+		t.Errorf("handlers called %d times after clear", calls)
+	}
+	sub.Unsubscribe()
+
+	b.Subscribe("hit", func(e Event) { calls++ })
+	b.Publish(testEvent("hit"))
+	b.Publish(nil)
+	if calls != 1 {
+		t.Errorf("new subscription called %d times, want 1", calls)
+	}
+}
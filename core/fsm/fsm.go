@@ -0,0 +1,197 @@
+// fsm 包，该包提供通用的有限状态机：状态含进入、退出与更新钩子，状态之间通过带守卫条件的转换切换，
+// 当前状态与状态变化可供外部观察，便于调试
+package fsm
+
+import "fmt"
+
+// AnyState, 转换来源通配名称，以其为来源的转换可从任一状态发起
+const AnyState = "*"
+
+// State, 状态对象，钩子均可为 nil
+type State struct {
+	Name string
+	// 进入状态时调用，参数为来源状态名称，初始状态的来源为空字符串
+	OnEnter func(from string)
+	// 离开状态时调用，参数为目标状态名称
+	OnExit func(to string)
+	// 处于该状态时每次更新调用
+	OnUpdate func(delta float64)
+}
+
+// Transition, 状态转换对象
+type Transition struct {
+	From, To string
+	// 守卫条件，返回 true 时执行转换，为 nil 时视为始终满足
+	Guard func() bool
+}
+
+// Machine, 有限状态机对象
+type Machine struct {
+	states      map[string]*State
+	transitions []Transition
+	current     *State
+	previous    string
+	elapsed     float64
+	// 状态变化回调，用于观察状态机
+	OnChange func(from, to string)
+}
+
+// NewMachine, Machine 类实例初始化函数
+// 返回值:
+//     Machine 类指针，不含任何状态
+func NewMachine() *Machine {
+	return &Machine{
+		states:      make(map[string]*State),
+		transitions: make([]Transition, 0),
+	}
+}
+
+// AddState, Machine 类添加状态的方法
+// 参数:
+//     states: State 类指针列表
+// 返回值:
+//     error 类型，状态名称为空或重复时返回错误
+func (m *Machine) AddState(states ...*State) error {
+	for _, s := range states {
+		if s.Name == "" || s.Name == AnyState {
+			return fmt.Errorf("invalid state name %q", s.Name)
+		}
+		if _, ok := m.states[s.Name]; ok {
+			return fmt.Errorf("state %q already exists", s.Name)
+		}
+		m.states[s.Name] = s
+	}
+	return nil
+}
+
+// AddTransition, Machine 类添加状态转换的方法，同一来源的转换按添加顺序判定，首个守卫条件满足的转换生效
+// 参数:
+//     from: 来源状态名称，为 AnyState 时可从任一状态发起
+//     to: 目标状态名称
+//     guard: 守卫条件
+// 返回值:
+//     error 类型，状态不存在时返回错误
+func (m *Machine) AddTransition(from, to string, guard func() bool) error {
+	if _, ok := m.states[from]; !ok && from != AnyState {
+		return fmt.Errorf("unknown state %q", from)
+	}
+	if _, ok := m.states[to]; !ok {
+		return fmt.Errorf("unknown state %q", to)
+	}
+	m.transitions = append(m.transitions, Transition{From: from, To: to, Guard: guard})
+	return nil
+}
+
+// Start, Machine 类以指定状态启动状态机的方法
+// 参数:
+//     name: 初始状态名称
+// 返回值:
+//     error 类型，状态不存在时返回错误
+func (m *Machine) Start(name string) error {
+	s, ok := m.states[name]
+	if !ok {
+		return fmt.Errorf("unknown state %q", name)
+	}
+	m.current = nil
+	m.enter(s, "")
+	return nil
+}
+
+// Set, Machine 类强制切换至指定状态的方法，不判定守卫条件，目标为当前状态时将重新进入该状态
+// 参数:
+//     name: 目标状态名称
+// 返回值:
+//     error 类型，状态不存在时返回错误
+func (m *Machine) Set(name string) error {
+	s, ok := m.states[name]
+	if !ok {
+		return fmt.Errorf("unknown state %q", name)
+	}
+	m.change(s)
+	return nil
+}
+
+// Update, Machine 类更新状态机的方法，先判定当前状态的转换，再调用当前状态的更新钩子
+// 参数:
+//     delta: 与上次更新的时延
+func (m *Machine) Update(delta float64) {
+	if m.current == nil {
+		return
+	}
+	m.elapsed += delta
+	for _, t := range m.transitions {
+		if t.From != m.current.Name && (t.From != AnyState || t.To == m.current.Name) {
+			continue
+		}
+		if t.Guard == nil || t.Guard() {
+			m.change(m.states[t.To])
+			break
+		}
+	}
+	if m.current.OnUpdate != nil {
+		m.current.OnUpdate(delta)
+	}
+}
+
+// Current, Machine 类获取当前状态名称的方法
+// 返回值:
+//     string 类型，未启动时为空字符串
+func (m *Machine) Current() string {
+	if m.current == nil {
+		return ""
+	}
+	return m.current.Name
+}
+
+// Previous, Machine 类获取上一个状态名称的方法
+// 返回值:
+//     string 类型
+func (m *Machine) Previous() string {
+	return m.previous
+}
+
+// Is, Machine 类判断是否处于指定状态的方法
+// 参数:
+//     name: 状态名称
+// 返回值:
+//     bool 类型
+func (m *Machine) Is(name string) bool {
+	return m.Current() == name
+}
+
+// Elapsed, Machine 类获取处于当前状态时长的方法
+// 返回值:
+//     float64 类型，单位为秒
+func (m *Machine) Elapsed() float64 {
+	return m.elapsed
+}
+
+// change, Machine 类切换状态的包内方法
+// 参数:
+//     s: State 类指针，目标状态
+func (m *Machine) change(s *State) {
+	from := ""
+	if m.current != nil {
+		from = m.current.Name
+		if m.current.OnExit != nil {
+			m.current.OnExit(s.Name)
+		}
+	}
+	m.enter(s, from)
+}
+
+// enter, Machine 类进入状态的包内方法
+// 参数:
+//     s: State 类指针，目标状态
+//     from: 来源状态名称
+func (m *Machine) enter(s *State, from string) {
+	m.previous = from
+	m.current = s
+	m.elapsed = 0
+	if s.OnEnter != nil {
+		s.OnEnter(from)
+	}
+	if m.OnChange != nil {
+		m.OnChange(from, s.Name)
+	}
+}
@@ -0,0 +1,197 @@
+package fsm
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newMachine, 构建含 a、b、c 三个状态的状态机，各钩子调用依次记入 log
+func newMachine(log *[]string) *Machine {
+	m := NewMachine()
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		m.AddState(&State{
+			Name:    name,
+			OnEnter: func(from string) { *log = append(*log, "enter "+name+" from "+from) },
+			OnExit:  func(to string) { *log = append(*log, "exit "+name+" to "+to) },
+		})
+	}
+	m.OnChange = func(from, to string) { *log = append(*log, "change "+from+" "+to) }
+	return m
+}
+
+func always() bool { return true }
+func never() bool  { return false }
+
+func TestTransitionOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		add   func(m *Machine)
+		start string
+		want  string
+	}{
+		{"first matching wins", func(m *Machine) {
+			m.AddTransition("a", "b", always)
+			m.AddTransition("a", "c", always)
+		}, "a", "b"},
+		{"failed guard skipped", func(m *Machine) {
+			m.AddTransition("a", "b", never)
+			m.AddTransition("a", "c", always)
+		}, "a", "c"},
+		{"nil guard always passes", func(m *Machine) {
+			m.AddTransition("a", "c", nil)
+		}, "a", "c"},
+		{"other source ignored", func(m *Machine) {
+			m.AddTransition("b", "c", always)
+		}, "a", "a"},
+		{"any state before specific", func(m *Machine) {
+			m.AddTransition(AnyState, "c", always)
+			m.AddTransition("a", "b", always)
+		}, "a", "c"},
+		{"any state skips current", func(m *Machine) {
+			m.AddTransition(AnyState, "a", always)
+			m.AddTransition("a", "b", always)
+		}, "a", "b"},
+		{"no transition", func(m *Machine) {
+			m.AddTransition("a", "b", never)
+		}, "a", "a"},
+	}
+	for _, tt := range tests {
+		var log []string
+		m := newMachine(&log)
+		tt.add(m)
+		m.Start(tt.start)
+		m.Update(0.1)
+		if got := m.Current(); got != tt.want {
+			t.Errorf("%s: current %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAnyStateDoesNotReenter(t *testing.T) {
+	var log []string
+	m := newMachine(&log)
+	m.AddTransition(AnyState, "b", always)
+	m.Start("a")
+	m.Update(0.1)
+	log = log[:0]
+	for i := 0; i < 3; i++ {
+		m.Update(0.1)
+	}
+	if len(log) != 0 || !m.Is("b") {
+		t.Errorf("any state transition re-entered the current state: %v", log)
+	}
+	if e := m.Elapsed(); e < 0.29 || e > 0.31 {
+		t.Errorf("elapsed %v, want 0.3", e)
+	}
+}
+
+func TestHookOrder(t *testing.T) {
+	var log []string
+	m := newMachine(&log)
+	m.AddTransition("a", "b", always)
+	m.Start("a")
+	m.Update(0.1)
+	want := []string{
+		"enter a from ", "change  a",
+		"exit a to b", "enter b from a", "change a b",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("hooks %v, want %v", log, want)
+	}
+	if m.Previous() != "a" {
+		t.Errorf("previous %q", m.Previous())
+	}
+}
+
+func TestSetReentersCurrent(t *testing.T) {
+	var log []string
+	m := newMachine(&log)
+	m.Start("a")
+	m.Update(0.5)
+	log = log[:0]
+	if err := m.Set("a"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"exit a to a", "enter a from a", "change a a"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("hooks %v, want %v", log, want)
+	}
+	if m.Elapsed() != 0 {
+		t.Errorf("elapsed %v after re-entering", m.Elapsed())
+	}
+}
+
+func TestElapsedResetsOnChange(t *testing.T) {
+	var log []string
+	m := newMachine(&log)
+	elapsed := 0.0
+	m.AddTransition("a", "b", func() bool { return m.Elapsed() >= 0.3 })
+	m.Start("a")
+	updates := 0
+	for !m.Is("b") && updates < 10 {
+		elapsed = m.Elapsed()
+		m.Update(0.1)
+		updates++
+	}
+	if updates != 3 || elapsed < 0.19 {
+		t.Errorf("changed after %d updates, elapsed %v before the change", updates, elapsed)
+	}
+	if m.Elapsed() != 0 {
+		t.Errorf("elapsed %v after the change", m.Elapsed())
+	}
+	m.Update(0.1)
+	if e := m.Elapsed(); e < 0.09 || e > 0.11 {
+		t.Errorf("elapsed %v after one update in the new state", e)
+	}
+}
+
+func TestUpdateHook(t *testing.T) {
+	m := NewMachine()
+	var updated []string
+	for _, name := range []string{"a", "b"} {
+		name := name
+		m.AddState(&State{Name: name, OnUpdate: func(delta float64) { updated = append(updated, name) }})
+	}
+	m.AddTransition("a", "b", always)
+	m.Update(0.1)
+	if len(updated) != 0 || m.Current() != "" {
+		t.Fatalf("machine updated before start: %v", updated)
+	}
+	m.Start("a")
+	m.Update(0.1)
+	if !reflect.DeepEqual(updated, []string{"b"}) {
+		t.Errorf("updated %v, want the state after the transition", updated)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := NewMachine()
+	if err := m.AddState(&State{Name: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"empty state name", m.AddState(&State{})},
+		{"any state name", m.AddState(&State{Name: AnyState})},
+		{"duplicate state", m.AddState(&State{Name: "a"})},
+		{"unknown source", m.AddTransition("x", "a", nil)},
+		{"unknown target", m.AddTransition("a", "x", nil)},
+		{"any state target", m.AddTransition("a", AnyState, nil)},
+		{"start unknown", m.Start("x")},
+		{"set unknown", m.Set("x")},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+	if err := m.AddTransition(AnyState, "a", nil); err != nil {
+		t.Errorf("any state source: %v", err)
+	}
+	if m.Current() != "" {
+		t.Errorf("failed start left current %q", m.Current())
+	}
+}
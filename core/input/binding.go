@@ -0,0 +1,129 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Device, 输入设备类型
+type Device int
+
+const (
+	Keyboard Device = iota
+	GamepadButtonDevice
+	GamepadAxisDevice
+	MouseDevice
+)
+
+// devicePrefixes, 绑定配置中设备类型的前缀名称
+var devicePrefixes = map[Device]string{
+	Keyboard:            "Key",
+	GamepadButtonDevice: "Pad",
+	GamepadAxisDevice:   "Axis",
+	MouseDevice:         "Mouse",
+}
+
+// Binding, 输入绑定对象，由设备类型与该设备下的按键（或轴）编码组成
+type Binding struct {
+	Device Device
+	Code   int
+}
+
+// KeyBinding, 键盘按键绑定初始化函数
+// 参数:
+//     key: 键盘按键编码
+// 返回值:
+//     Binding 类
+func KeyBinding(key Key) Binding {
+	return Binding{Device: Keyboard, Code: int(key)}
+}
+
+// PadBinding, 手柄具名按键绑定初始化函数，匹配任一已连接手柄
+// 参数:
+//     button: 具名手柄按键
+// 返回值:
+//     Binding 类
+func PadBinding(button GamepadButton) Binding {
+	return Binding{Device: GamepadButtonDevice, Code: int(button)}
+}
+
+// AxisBinding, 手柄具名轴单方向绑定初始化函数，匹配任一已连接手柄
+// 参数:
+//     axis: 具名手柄轴
+//     positive: true 为正方向， false 为负方向
+// 返回值:
+//     Binding 类
+func AxisBinding(axis GamepadAxis, positive bool) Binding {
+	code := int(axis) * 2
+	if positive {
+		code++
+	}
+	return Binding{Device: GamepadAxisDevice, Code: code}
+}
+
+// ParseBinding, 根据绑定名称解析输入绑定的函数
+// 参数:
+//     name: 绑定名称，格式为 "设备前缀:按键名称"，如 "Key:Space"、"Pad:A"、"Axis:LeftX-"、"Mouse:Left"；省略前缀时视为键盘按键
+// 返回值:
+//     Binding 类
+//     error 类型，名称无法识别时返回错误
+func ParseBinding(name string) (Binding, error) {
+	prefix := devicePrefixes[Keyboard]
+	code := name
+	if i := strings.Index(name, ":"); i >= 0 {
+		prefix = name[:i]
+		code = name[i+1:]
+	}
+
+	switch prefix {
+	case devicePrefixes[Keyboard]:
+		key, err := ParseKey(code)
+		if err != nil {
+			return Binding{}, err
+		}
+		return KeyBinding(key), nil
+	case devicePrefixes[GamepadButtonDevice]:
+		button, err := ParseGamepadButton(code)
+		if err != nil {
+			return Binding{}, err
+		}
+		return PadBinding(button), nil
+	case devicePrefixes[GamepadAxisDevice]:
+		if len(code) < 2 || (code[len(code)-1] != '+' && code[len(code)-1] != '-') {
+			return Binding{}, fmt.Errorf("gamepad axis binding %q must end with '+' or '-'", name)
+		}
+		axis, err := ParseGamepadAxis(code[:len(code)-1])
+		if err != nil {
+			return Binding{}, err
+		}
+		return AxisBinding(axis, code[len(code)-1] == '+'), nil
+	case devicePrefixes[MouseDevice]:
+		button, err := ParseMouseButton(code)
+		if err != nil {
+			return Binding{}, err
+		}
+		return MouseBinding(button), nil
+	}
+	return Binding{}, fmt.Errorf("unknown input device %q in binding %q", prefix, name)
+}
+
+// String, Binding 类获取绑定名称的方法，与 ParseBinding 互为逆操作
+// 返回值:
+//     string 类型
+func (b Binding) String() string {
+	switch b.Device {
+	case Keyboard:
+		return devicePrefixes[Keyboard] + ":" + Key(b.Code).String()
+	case GamepadButtonDevice:
+		return devicePrefixes[GamepadButtonDevice] + ":" + GamepadButton(b.Code).String()
+	case GamepadAxisDevice:
+		sign := "-"
+		if b.Code%2 == 1 {
+			sign = "+"
+		}
+		return devicePrefixes[GamepadAxisDevice] + ":" + GamepadAxis(b.Code/2).String() + sign
+	case MouseDevice:
+		return devicePrefixes[MouseDevice] + ":" + MouseButton(b.Code).String()
+	}
+	return fmt.Sprintf("%d:%d", b.Device, b.Code)
+}
@@ -0,0 +1,96 @@
+package input
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Config, 输入绑定配置对象，对应 JSON 格式的绑定配置文件
+// 示例:
+//     {
+//         "actions": {"jump": ["Key:Up", "Key:W"], "fire": ["Key:J"]},
+//         "axes": {"move_x": {"negative": "move_left", "positive": "move_right"}}
+//     }
+type Config struct {
+	Actions map[string][]string `json:"actions"`
+	Axes    map[string]Axis     `json:"axes"`
+}
+
+// LoadConfig, Manager 类从配置文件加载绑定的方法，配置中出现的动作将替换原有绑定
+// 参数:
+//     file: 配置文件路径
+// 返回值:
+//     error 类型，读取或解析失败时返回错误
+func (m *Manager) LoadConfig(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	return m.ApplyConfig(c)
+}
+
+// SaveConfig, Manager 类将当前绑定保存至配置文件的方法
+// 参数:
+//     file: 配置文件路径
+// 返回值:
+//     error 类型，写入失败时返回错误
+func (m *Manager) SaveConfig(file string) error {
+	data, err := json.MarshalIndent(m.Config(), "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// ApplyConfig, Manager 类应用绑定配置的方法，配置中任一绑定无法解析时不做任何修改
+// 参数:
+//     c: Config 类
+// 返回值:
+//     error 类型，绑定名称无法解析时返回错误
+func (m *Manager) ApplyConfig(c Config) error {
+	parsed := make(map[string][]Binding, len(c.Actions))
+	for action, names := range c.Actions {
+		bindings := make([]Binding, 0, len(names))
+		for _, name := range names {
+			b, err := ParseBinding(name)
+			if err != nil {
+				return err
+			}
+			bindings = append(bindings, b)
+		}
+		parsed[action] = bindings
+	}
+
+	for action, bindings := range parsed {
+		m.Rebind(action, bindings...)
+	}
+	for name, axis := range c.Axes {
+		m.BindAxis(name, axis.Negative, axis.Positive)
+	}
+	return nil
+}
+
+// Config, Manager 类导出当前绑定配置的方法
+// 返回值:
+//     Config 类
+func (m *Manager) Config() Config {
+	c := Config{
+		Actions: make(map[string][]string, len(m.bindings)),
+		Axes:    make(map[string]Axis, len(m.axes)),
+	}
+	for _, action := range m.Actions() {
+		names := make([]string, 0, len(m.bindings[action]))
+		for _, b := range m.bindings[action] {
+			names = append(names, b.String())
+		}
+		c.Actions[action] = names
+	}
+	for name, axis := range m.axes {
+		c.Axes[name] = axis
+	}
+	return c
+}
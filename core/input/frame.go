@@ -0,0 +1,75 @@
+package input
+
+// Frame, 单次逻辑更新的输入快照，记录各动作强度及鼠标状态，用于输入录制与回放
+type Frame struct {
+	Values           map[string]float32
+	MouseX, MouseY   float32
+	ScrollX, ScrollY float32
+}
+
+// FrameSource, 输入快照来源接口对象，回放时代替输入设备提供每次更新的动作状态
+type FrameSource interface {
+	// NextFrame 返回下一次更新的输入快照，无更多快照时第二个返回值为 false
+	NextFrame() (Frame, bool)
+}
+
+// FrameSink, 输入快照接收接口对象，录制时在每次更新后接收当前动作状态
+type FrameSink interface {
+	RecordFrame(Frame)
+}
+
+// Frame, Manager 类获取当前输入快照的方法
+// 返回值:
+//     Frame 类，仅包含强度不为 0 的动作
+func (m *Manager) Frame() Frame {
+	f := Frame{
+		Values:  make(map[string]float32),
+		MouseX:  m.mouseX,
+		MouseY:  m.mouseY,
+		ScrollX: m.scrollX,
+		ScrollY: m.scrollY,
+	}
+	for action, state := range m.states {
+		if state.value != 0 {
+			f.Values[action] = state.value
+		}
+	}
+	return f
+}
+
+// SetRecorder, Manager 类设置输入录制对象的方法，此后每次 Update 结束时将当前输入快照交给该对象
+// 参数:
+//     sink: FrameSink 接口对象，为 nil 时停止录制
+func (m *Manager) SetRecorder(sink FrameSink) {
+	m.recorder = sink
+}
+
+// SetPlayback, Manager 类设置输入回放来源的方法，回放期间忽略全部输入设备，
+// 动作状态完全由回放来源提供，回放结束后自动恢复为设备输入
+// 参数:
+//     source: FrameSource 接口对象，为 nil 时停止回放
+func (m *Manager) SetPlayback(source FrameSource) {
+	m.playback = source
+}
+
+// Playing, Manager 类判断是否处于回放状态的方法
+// 返回值:
+//     bool 类型
+func (m *Manager) Playing() bool {
+	return m.playback != nil
+}
+
+// nextPlaybackFrame, Manager 类获取下一个回放快照的包内方法，回放结束时停止回放
+// 返回值:
+//     Frame 类
+//     bool 类型， true 为获取成功
+func (m *Manager) nextPlaybackFrame() (Frame, bool) {
+	if m.playback == nil {
+		return Frame{}, false
+	}
+	f, ok := m.playback.NextFrame()
+	if !ok {
+		m.playback = nil
+	}
+	return f, ok
+}
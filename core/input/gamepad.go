@@ -0,0 +1,441 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+)
+
+// MaxJoysticks, 同时支持的手柄（摇杆）设备数量，与 GLFW 一致
+const MaxJoysticks = 16
+
+// JoystickSource, 手柄设备输入源接口对象，由窗口层实现（如 glfw 的 joystick 接口），
+// 无显示设备的环境下可由 VirtualJoysticks 等虚拟设备替代
+type JoystickSource interface {
+	Present(joy int) bool
+	Name(joy int) string
+	Axes(joy int) []float32
+	Buttons(joy int) []bool
+}
+
+// GamepadButton, 具名手柄按键
+type GamepadButton int
+
+const (
+	GamepadA GamepadButton = iota
+	GamepadB
+	GamepadX
+	GamepadY
+	GamepadLeftBumper
+	GamepadRightBumper
+	GamepadBack
+	GamepadStart
+	GamepadGuide
+	GamepadLeftThumb
+	GamepadRightThumb
+	GamepadDpadUp
+	GamepadDpadRight
+	GamepadDpadDown
+	GamepadDpadLeft
+	GamepadButtonLast = GamepadDpadLeft
+)
+
+// GamepadAxis, 具名手柄轴
+type GamepadAxis int
+
+const (
+	GamepadLeftX GamepadAxis = iota
+	GamepadLeftY
+	GamepadRightX
+	GamepadRightY
+	GamepadLeftTrigger
+	GamepadRightTrigger
+	GamepadAxisLast = GamepadRightTrigger
+)
+
+var gamepadButtonNames = []string{
+	"A", "B", "X", "Y", "LeftBumper", "RightBumper", "Back", "Start", "Guide",
+	"LeftThumb", "RightThumb", "DpadUp", "DpadRight", "DpadDown", "DpadLeft",
+}
+
+var gamepadAxisNames = []string{
+	"LeftX", "LeftY", "RightX", "RightY", "LeftTrigger", "RightTrigger",
+}
+
+// ParseGamepadButton, 根据名称获取具名手柄按键的函数
+// 参数:
+//     name: 按键名称，如 "A"、"Start"、"DpadLeft"
+// 返回值:
+//     GamepadButton 类型
+//     error 类型，名称无法识别时返回错误
+func ParseGamepadButton(name string) (GamepadButton, error) {
+	for i, n := range gamepadButtonNames {
+		if n == name {
+			return GamepadButton(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gamepad button %q", name)
+}
+
+// String, GamepadButton 类获取按键名称的方法
+func (b GamepadButton) String() string {
+	if b >= 0 && b <= GamepadButtonLast {
+		return gamepadButtonNames[b]
+	}
+	return fmt.Sprintf("Button%d", int(b))
+}
+
+// ParseGamepadAxis, 根据名称获取具名手柄轴的函数
+// 参数:
+//     name: 轴名称，如 "LeftX"、"RightTrigger"
+// 返回值:
+//     GamepadAxis 类型
+//     error 类型，名称无法识别时返回错误
+func ParseGamepadAxis(name string) (GamepadAxis, error) {
+	for i, n := range gamepadAxisNames {
+		if n == name {
+			return GamepadAxis(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gamepad axis %q", name)
+}
+
+// String, GamepadAxis 类获取轴名称的方法
+func (a GamepadAxis) String() string {
+	if a >= 0 && a <= GamepadAxisLast {
+		return gamepadAxisNames[a]
+	}
+	return fmt.Sprintf("Axis%d", int(a))
+}
+
+// GamepadMapping, 手柄映射对象，定义某型号手柄原始按键、轴序号与具名按键、轴的对应关系
+type GamepadMapping struct {
+	Name    string         `json:"name"`
+	Buttons map[string]int `json:"buttons"`
+	Axes    map[string]int `json:"axes"`
+	// Inverted, 需反转方向的轴名称列表
+	Inverted []string `json:"inverted"`
+}
+
+// DefaultGamepadMapping, 默认手柄映射（Xbox 布局，方向键作为按键排在其余按键之后），手柄名称不在映射数据库中时使用，
+// 按键序号与 GamepadButton 次序一致
+var DefaultGamepadMapping = &GamepadMapping{
+	Name: "default",
+	Buttons: map[string]int{
+		"A": 0, "B": 1, "X": 2, "Y": 3,
+		"LeftBumper": 4, "RightBumper": 5, "Back": 6, "Start": 7, "Guide": 8,
+		"LeftThumb": 9, "RightThumb": 10,
+		"DpadUp": 11, "DpadRight": 12, "DpadDown": 13, "DpadLeft": 14,
+	},
+	Axes: map[string]int{
+		"LeftX": 0, "LeftY": 1, "RightX": 2, "RightY": 3,
+		"LeftTrigger": 4, "RightTrigger": 5,
+	},
+}
+
+// Gamepad, 已连接的手柄对象，保存按具名按键、轴整理后的当前状态
+type Gamepad struct {
+	ID      int
+	Name    string
+	Mapping *GamepadMapping
+	buttons [GamepadButtonLast + 1]bool
+	axes    [GamepadAxisLast + 1]float32
+}
+
+// Button, Gamepad 类判断具名按键是否按下的方法
+// 参数:
+//     b: 具名手柄按键
+// 返回值:
+//     bool 类型
+func (g *Gamepad) Button(b GamepadButton) bool {
+	if b < 0 || b > GamepadButtonLast {
+		return false
+	}
+	return g.buttons[b]
+}
+
+// Axis, Gamepad 类获取具名轴数值的方法，已应用死区处理
+// 参数:
+//     a: 具名手柄轴
+// 返回值:
+//     float32 类型，摇杆取值 [-1, 1]，扳机取值 [0, 1]
+func (g *Gamepad) Axis(a GamepadAxis) float32 {
+	if a < 0 || a > GamepadAxisLast {
+		return 0
+	}
+	return g.axes[a]
+}
+
+// update, Gamepad 类根据原始输入刷新状态的包内方法
+// 参数:
+//     rawAxes: 原始轴数值
+//     rawButtons: 原始按键状态
+//     stickDeadzone, triggerDeadzone: 摇杆与扳机死区
+func (g *Gamepad) update(rawAxes []float32, rawButtons []bool, stickDeadzone, triggerDeadzone float32) {
+	for i, name := range gamepadButtonNames {
+		idx, ok := g.Mapping.Buttons[name]
+		g.buttons[i] = ok && idx >= 0 && idx < len(rawButtons) && rawButtons[idx]
+	}
+
+	var raw [GamepadAxisLast + 1]float32
+	// 未映射的扳机视为松开状态
+	raw[GamepadLeftTrigger], raw[GamepadRightTrigger] = -1, -1
+	for i, name := range gamepadAxisNames {
+		idx, ok := g.Mapping.Axes[name]
+		if !ok || idx < 0 || idx >= len(rawAxes) {
+			continue
+		}
+		raw[i] = rawAxes[idx]
+		for _, inverted := range g.Mapping.Inverted {
+			if inverted == name {
+				raw[i] = -raw[i]
+			}
+		}
+	}
+
+	g.axes[GamepadLeftX], g.axes[GamepadLeftY] = radialDeadzone(raw[GamepadLeftX], raw[GamepadLeftY], stickDeadzone)
+	g.axes[GamepadRightX], g.axes[GamepadRightY] = radialDeadzone(raw[GamepadRightX], raw[GamepadRightY], stickDeadzone)
+	// 扳机原始取值为 [-1, 1]，松开时为 -1
+	g.axes[GamepadLeftTrigger] = linearDeadzone((raw[GamepadLeftTrigger]+1)/2, triggerDeadzone)
+	g.axes[GamepadRightTrigger] = linearDeadzone((raw[GamepadRightTrigger]+1)/2, triggerDeadzone)
+}
+
+// SetJoystickSource, Manager 类设置手柄输入源的方法
+// 参数:
+//     source: JoystickSource 接口对象，为 nil 时断开全部手柄
+func (m *Manager) SetJoystickSource(source JoystickSource) {
+	m.joysticks = source
+	m.RefreshGamepads()
+}
+
+// AddGamepadMapping, Manager 类向手柄映射数据库添加映射的方法，同名映射将被替换
+// 参数:
+//     mappings: GamepadMapping 类指针列表，以 Name 匹配手柄名称
+func (m *Manager) AddGamepadMapping(mappings ...*GamepadMapping) {
+	for _, mapping := range mappings {
+		m.gamepadMappings[mapping.Name] = mapping
+	}
+}
+
+// LoadGamepadMappings, Manager 类从 JSON 文件加载手柄映射数据库的方法
+// 参数:
+//     file: 映射文件路径，内容为 GamepadMapping 数组
+// 返回值:
+//     error 类型，读取或解析失败时返回错误
+func (m *Manager) LoadGamepadMappings(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	mappings := make([]*GamepadMapping, 0)
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return err
+	}
+	m.AddGamepadMapping(mappings...)
+	return nil
+}
+
+// Gamepad, Manager 类获取已连接手柄的方法
+// 参数:
+//     joy: 手柄序号
+// 返回值:
+//     Gamepad 类指针，未连接时为 nil
+func (m *Manager) Gamepad(joy int) *Gamepad {
+	if joy < 0 || joy >= MaxJoysticks {
+		return nil
+	}
+	return m.gamepads[joy]
+}
+
+// Gamepads, Manager 类获取全部已连接手柄的方法
+// 返回值:
+//     Gamepad 类指针切片，按手柄序号排列
+func (m *Manager) Gamepads() []*Gamepad {
+	pads := make([]*Gamepad, 0)
+	for _, pad := range m.gamepads {
+		if pad != nil {
+			pads = append(pads, pad)
+		}
+	}
+	return pads
+}
+
+// RefreshGamepads, Manager 类检测手柄插拔并刷新手柄状态的方法，每次 Update 时自动调用，
+// 也可在窗口层收到手柄连接事件时立即调用；同一序号换接了其他手柄时按断开后重新连接处理
+func (m *Manager) RefreshGamepads() {
+	for joy := 0; joy < MaxJoysticks; joy++ {
+		present := m.joysticks != nil && m.joysticks.Present(joy)
+		pad := m.gamepads[joy]
+
+		if present && pad != nil && m.joysticks.Name(joy) != pad.Name {
+			m.gamepads[joy] = nil
+			if m.OnGamepadDisconnected != nil {
+				m.OnGamepadDisconnected(pad)
+			}
+			pad = nil
+		}
+
+		switch {
+		case present && pad == nil:
+			name := m.joysticks.Name(joy)
+			mapping, ok := m.gamepadMappings[name]
+			if !ok {
+				mapping = DefaultGamepadMapping
+			}
+			pad = &Gamepad{ID: joy, Name: name, Mapping: mapping}
+			m.gamepads[joy] = pad
+			if m.OnGamepadConnected != nil {
+				m.OnGamepadConnected(pad)
+			}
+		case !present && pad != nil:
+			m.gamepads[joy] = nil
+			if m.OnGamepadDisconnected != nil {
+				m.OnGamepadDisconnected(pad)
+			}
+			continue
+		case !present:
+			continue
+		}
+
+		pad.update(m.joysticks.Axes(joy), m.joysticks.Buttons(joy), m.StickDeadzone, m.TriggerDeadzone)
+	}
+}
+
+// gamepadButtonValue, Manager 类获取任一手柄具名按键输入强度的包内方法
+// 参数:
+//     b: 具名手柄按键
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func (m *Manager) gamepadButtonValue(b GamepadButton) float32 {
+	for _, pad := range m.gamepads {
+		if pad != nil && pad.Button(b) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// gamepadAxisValue, Manager 类获取任一手柄具名轴单方向输入强度的包内方法
+// 参数:
+//     a: 具名手柄轴
+//     positive: true 为正方向， false 为负方向
+// 返回值:
+//     float32 类型，取值 [0, 1]，为各手柄中的最大值
+func (m *Manager) gamepadAxisValue(a GamepadAxis, positive bool) float32 {
+	value := float32(0)
+	for _, pad := range m.gamepads {
+		if pad == nil {
+			continue
+		}
+		v := pad.Axis(a)
+		if !positive {
+			v = -v
+		}
+		if v > value {
+			value = v
+		}
+	}
+	return value
+}
+
+// radialDeadzone, 对摇杆两轴做径向死区处理的包内函数，死区外的数值重新映射至 [0, 1]
+// 参数:
+//     x, y: 摇杆原始数值
+//     deadzone: 死区半径
+// 返回值:
+//     float32, float32 类型，处理后的数值
+func radialDeadzone(x, y, deadzone float32) (float32, float32) {
+	length := float32(math.Sqrt(float64(x*x + y*y)))
+	if length <= deadzone || length == 0 {
+		return 0, 0
+	}
+	scale := (length - deadzone) / (1 - deadzone) / length
+	if length*scale > 1 {
+		scale = 1 / length
+	}
+	return x * scale, y * scale
+}
+
+// linearDeadzone, 对单轴做线性死区处理的包内函数
+// 参数:
+//     v: 原始数值，取值 [0, 1]
+//     deadzone: 死区大小
+// 返回值:
+//     float32 类型，处理后的数值
+func linearDeadzone(v, deadzone float32) float32 {
+	if v <= deadzone {
+		return 0
+	}
+	v = (v - deadzone) / (1 - deadzone)
+	if v > 1 {
+		v = 1
+	}
+	return v
+}
+
+// VirtualJoysticks, 虚拟手柄输入源，JoystickSource 接口的内存实现，
+// 用于在无显示设备的环境（如测试、回放）中模拟手柄插拔与输入
+type VirtualJoysticks struct {
+	Devices [MaxJoysticks]*VirtualJoystick
+}
+
+// VirtualJoystick, 虚拟手柄对象，直接设置原始轴数值与按键状态
+type VirtualJoystick struct {
+	Name    string
+	Axes    []float32
+	Buttons []bool
+}
+
+// Connect, VirtualJoysticks 类连接虚拟手柄的方法，原始轴数值初始为 0，模拟扳机松开状态时应设为 -1
+// 参数:
+//     joy: 手柄序号
+//     name: 手柄名称
+//     axes, buttons: 原始轴与按键数量
+// 返回值:
+//     VirtualJoystick 类指针
+func (v *VirtualJoysticks) Connect(joy int, name string, axes, buttons int) *VirtualJoystick {
+	device := &VirtualJoystick{
+		Name:    name,
+		Axes:    make([]float32, axes),
+		Buttons: make([]bool, buttons),
+	}
+	v.Devices[joy] = device
+	return device
+}
+
+// Disconnect, VirtualJoysticks 类断开虚拟手柄的方法
+// 参数:
+//     joy: 手柄序号
+func (v *VirtualJoysticks) Disconnect(joy int) {
+	v.Devices[joy] = nil
+}
+
+// Present, VirtualJoysticks 类 JoystickSource.Present(joy int) bool 的实现
+func (v *VirtualJoysticks) Present(joy int) bool {
+	return joy >= 0 && joy < MaxJoysticks && v.Devices[joy] != nil
+}
+
+// Name, VirtualJoysticks 类 JoystickSource.Name(joy int) string 的实现
+func (v *VirtualJoysticks) Name(joy int) string {
+	if !v.Present(joy) {
+		return ""
+	}
+	return v.Devices[joy].Name
+}
+
+// Axes, VirtualJoysticks 类 JoystickSource.Axes(joy int) []float32 的实现
+func (v *VirtualJoysticks) Axes(joy int) []float32 {
+	if !v.Present(joy) {
+		return nil
+	}
+	return v.Devices[joy].Axes
+}
+
+// Buttons, VirtualJoysticks 类 JoystickSource.Buttons(joy int) []bool 的实现
+func (v *VirtualJoysticks) Buttons(joy int) []bool {
+	if !v.Present(joy) {
+		return nil
+	}
+	return v.Devices[joy].Buttons
+}
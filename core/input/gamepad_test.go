@@ -0,0 +1,150 @@
+package input
+
+import "testing"
+
+// newPadManager, 构建连接一个虚拟手柄的默认输入管理器，映射数据库加载自示例配置
+func newPadManager(t *testing.T, name string) (*Manager, *VirtualJoystick) {
+	m := NewDefaultManager()
+	if err := m.LoadGamepadMappings("../../resource/config/gamepads.json"); err != nil {
+		t.Fatal(err)
+	}
+	pads := &VirtualJoysticks{}
+	device := pads.Connect(0, name, 6, 15)
+	// 扳机松开时原始数值为 -1
+	device.Axes[2], device.Axes[4], device.Axes[5] = -1, -1, -1
+	m.SetJoystickSource(pads)
+	return m, device
+}
+
+func TestDefaultMappingMatchesXbox(t *testing.T) {
+	m, _ := newPadManager(t, "Xbox 360 Controller")
+	xbox := m.gamepadMappings["Xbox 360 Controller"]
+	for _, name := range gamepadButtonNames {
+		want, ok := DefaultGamepadMapping.Buttons[name]
+		got, xok := xbox.Buttons[name]
+		if !ok || !xok || got != want {
+			t.Errorf("button %s: default %d (%v), xbox %d (%v)", name, want, ok, got, xok)
+		}
+	}
+}
+
+func TestVirtualJoystickActions(t *testing.T) {
+	for _, name := range []string{"Xbox 360 Controller", "Unknown Pad"} {
+		m, device := newPadManager(t, name)
+		mapping := m.Gamepad(0).Mapping
+		tests := []struct {
+			button string
+			action string
+		}{
+			{"A", ActionJump},
+			{"B", ActionDash},
+			{"DpadUp", ActionMoveUp},
+			{"DpadRight", ActionMoveRight},
+			{"DpadDown", ActionMoveDown},
+			{"DpadLeft", ActionMoveLeft},
+			{"RightBumper", ActionNextWeapon},
+		}
+		for _, tt := range tests {
+			device.Buttons[mapping.Buttons[tt.button]] = true
+			m.Update(1.0 / 60)
+			if !m.JustPressed(tt.action) {
+				t.Errorf("%s: button %s did not press %s", name, tt.button, tt.action)
+			}
+			device.Buttons[mapping.Buttons[tt.button]] = false
+			m.Update(1.0 / 60)
+			if m.Pressed(tt.action) {
+				t.Errorf("%s: %s still pressed after releasing %s", name, tt.action, tt.button)
+			}
+		}
+	}
+}
+
+func TestVirtualJoystickAxes(t *testing.T) {
+	m, device := newPadManager(t, "Xbox 360 Controller")
+	xbox := m.Gamepad(0).Mapping
+
+	// 死区内不触发
+	device.Axes[xbox.Axes["LeftX"]] = 0.1
+	m.Update(1.0 / 60)
+	if v := m.Axis(AxisMoveX); v != 0 {
+		t.Errorf("stick inside deadzone gave move_x %v", v)
+	}
+
+	device.Axes[xbox.Axes["LeftX"]] = -1
+	m.Update(1.0 / 60)
+	if v := m.Axis(AxisMoveX); v != -1 {
+		t.Errorf("full left stick gave move_x %v", v)
+	}
+	device.Axes[xbox.Axes["LeftX"]] = 0
+
+	device.Axes[xbox.Axes["LeftTrigger"]] = 1
+	m.Update(1.0 / 60)
+	if !m.Pressed(ActionGlide) {
+		t.Error("left trigger did not press glide")
+	}
+}
+
+func TestVirtualJoystickHotplug(t *testing.T) {
+	m := NewDefaultManager()
+	var connected, disconnected int
+	m.OnGamepadConnected = func(pad *Gamepad) { connected++ }
+	m.OnGamepadDisconnected = func(pad *Gamepad) { disconnected++ }
+	pads := &VirtualJoysticks{}
+	m.SetJoystickSource(pads)
+
+	device := pads.Connect(1, "Unknown Pad", 6, 15)
+	device.Buttons[DefaultGamepadMapping.Buttons["A"]] = true
+	m.Update(1.0 / 60)
+	if connected != 1 || m.Gamepad(1) == nil || m.Gamepad(1).Mapping != DefaultGamepadMapping {
+		t.Fatalf("pad not connected with the default mapping: %d connected", connected)
+	}
+	if !m.Pressed(ActionJump) {
+		t.Error("jump not pressed on hot-plugged pad")
+	}
+
+	pads.Disconnect(1)
+	m.Update(1.0 / 60)
+	if disconnected != 1 || m.Gamepad(1) != nil || len(m.Gamepads()) != 0 {
+		t.Fatalf("pad not disconnected: %d disconnected", disconnected)
+	}
+	if m.Pressed(ActionJump) {
+		t.Error("jump still pressed after disconnect")
+	}
+}
+
+func TestGamepadSwappedInSameSlot(t *testing.T) {
+	m, _ := newPadManager(t, "Xbox 360 Controller")
+	var log []string
+	m.OnGamepadConnected = func(pad *Gamepad) { log = append(log, "connect "+pad.Name) }
+	m.OnGamepadDisconnected = func(pad *Gamepad) { log = append(log, "disconnect "+pad.Name) }
+	m.Update(1.0 / 60)
+	xbox := m.Gamepad(0)
+	if xbox == nil || xbox.Mapping != m.gamepadMappings["Xbox 360 Controller"] {
+		t.Fatalf("xbox pad not mapped")
+	}
+
+	// 两次刷新之间拔出手柄并在同一序号接入另一手柄
+	pads := m.joysticks.(*VirtualJoysticks)
+	ps3 := "Sony PLAYSTATION(R)3 Controller"
+	device := pads.Connect(0, ps3, 6, 17)
+	m.Update(1.0 / 60)
+	want := []string{"disconnect Xbox 360 Controller", "connect " + ps3}
+	if len(log) != len(want) || log[0] != want[0] || log[1] != want[1] {
+		t.Fatalf("callbacks %v, want %v", log, want)
+	}
+	pad := m.Gamepad(0)
+	if pad == xbox || pad.Name != ps3 || pad.Mapping != m.gamepadMappings[ps3] {
+		t.Fatalf("slot not re-mapped: %+v", pad)
+	}
+	device.Buttons[pad.Mapping.Buttons["A"]] = true
+	m.Update(1.0 / 60)
+	if !m.JustPressed(ActionJump) {
+		t.Error("jump not pressed with the new mapping")
+	}
+
+	log = nil
+	m.Update(1.0 / 60)
+	if len(log) != 0 || m.Gamepad(0) != pad {
+		t.Errorf("unchanged pad re-mapped: %v", log)
+	}
+}
@@ -0,0 +1,112 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Key, 键盘按键编码，取值与 GLFW 按键编码一致，便于窗口层直接转换
+type Key int
+
+// 常用键盘按键编码
+const (
+	KeyUnknown      Key = -1
+	KeySpace        Key = 32
+	Key0            Key = 48
+	Key1            Key = 49
+	Key9            Key = 57
+	KeyA            Key = 65
+	KeyD            Key = 68
+	KeyE            Key = 69
+	KeyJ            Key = 74
+	KeyK            Key = 75
+	KeyL            Key = 76
+	KeyQ            Key = 81
+	KeyR            Key = 82
+	KeyS            Key = 83
+	KeyW            Key = 87
+	KeyZ            Key = 90
+	KeyEscape       Key = 256
+	KeyEnter        Key = 257
+	KeyTab          Key = 258
+	KeyBackspace    Key = 259
+	KeyRight        Key = 262
+	KeyLeft         Key = 263
+	KeyDown         Key = 264
+	KeyUp           Key = 265
+	KeyF1           Key = 290
+	KeyF12          Key = 301
+	KeyLeftShift    Key = 340
+	KeyLeftControl  Key = 341
+	KeyLeftAlt      Key = 342
+	KeyRightShift   Key = 344
+	KeyRightControl Key = 345
+	KeyRightAlt     Key = 346
+	KeyLast         Key = 348
+)
+
+// keyNames, 按键名称与编码的对应关系，用于绑定配置的读写
+var keyNames = map[string]Key{
+	"Space":        KeySpace,
+	"Escape":       KeyEscape,
+	"Enter":        KeyEnter,
+	"Tab":          KeyTab,
+	"Backspace":    KeyBackspace,
+	"Right":        KeyRight,
+	"Left":         KeyLeft,
+	"Down":         KeyDown,
+	"Up":           KeyUp,
+	"LeftShift":    KeyLeftShift,
+	"LeftControl":  KeyLeftControl,
+	"LeftAlt":      KeyLeftAlt,
+	"RightShift":   KeyRightShift,
+	"RightControl": KeyRightControl,
+	"RightAlt":     KeyRightAlt,
+}
+
+// keyCodeNames, 按键编码与名称的对应关系，由 keyNames 生成
+var keyCodeNames = make(map[Key]string)
+
+func init() {
+	for k := KeyA; k <= KeyZ; k++ {
+		keyNames[string(rune(k))] = k
+	}
+	for k := Key0; k <= Key9; k++ {
+		keyNames[string(rune(k))] = k
+	}
+	for k := KeyF1; k <= KeyF12; k++ {
+		keyNames[fmt.Sprintf("F%d", k-KeyF1+1)] = k
+	}
+	for name, k := range keyNames {
+		keyCodeNames[k] = name
+	}
+}
+
+// ParseKey, 根据按键名称获取按键编码的函数
+// 参数:
+//     name: 按键名称，如 "A"、"Space"、"Left"、"F1"，也可以 "#" 加按键编码数值表示，如 "#96"
+// 返回值:
+//     Key 类型，按键编码
+//     error 类型，名称无法识别时返回错误
+func ParseKey(name string) (Key, error) {
+	if k, ok := keyNames[name]; ok {
+		return k, nil
+	}
+	if strings.HasPrefix(name, "#") {
+		if code, err := strconv.Atoi(name[1:]); err == nil && code >= 0 && Key(code) <= KeyLast {
+			return Key(code), nil
+		}
+	}
+	return KeyUnknown, fmt.Errorf("unknown key name %q", name)
+}
+
+// String, Key 类获取按键名称的方法
+// 返回值:
+//     string 类型，无对应名称时返回 "#" 加编码数值
+func (k Key) String() string {
+	if name, ok := keyCodeNames[k]; ok {
+		return name
+	}
+	return "#" + strconv.Itoa(int(k))
+}
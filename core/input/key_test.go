@@ -0,0 +1,57 @@
+package input
+
+import "testing"
+
+func TestKeyString(t *testing.T) {
+	tests := []struct {
+		key  Key
+		want string
+	}{
+		{KeySpace, "Space"},
+		{KeyA, "A"},
+		{Key0 + 5, "5"},
+		{KeyF12, "F12"},
+		{KeyLeftShift, "LeftShift"},
+		{Key(96), "#96"},
+		{Key(5), "#5"},
+		{KeyLast, "#348"},
+	}
+	for _, tt := range tests {
+		if got := tt.key.String(); got != tt.want {
+			t.Errorf("Key(%d).String() = %q, want %q", int(tt.key), got, tt.want)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want Key
+		ok   bool
+	}{
+		{"Space", KeySpace, true},
+		{"5", Key0 + 5, true},
+		{"#5", Key(5), true},
+		{"#96", Key(96), true},
+		{"96", KeyUnknown, false},
+		{"#", KeyUnknown, false},
+		{"#-1", KeyUnknown, false},
+		{"#99999", KeyUnknown, false},
+		{"Hyper", KeyUnknown, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseKey(tt.name)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("ParseKey(%q) = %d, %v, want %d, ok %v", tt.name, int(got), err, int(tt.want), tt.ok)
+		}
+	}
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	for k := Key(0); k <= KeyLast; k++ {
+		got, err := ParseKey(k.String())
+		if err != nil || got != k {
+			t.Errorf("Key(%d) written as %q parsed as %d, %v", int(k), k.String(), int(got), err)
+		}
+	}
+}
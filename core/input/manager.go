@@ -0,0 +1,381 @@
+// input 包，该包将具体输入设备的按键映射为具名动作（如 "move_left"、"jump"、"fire"）与轴，
+// 游戏逻辑仅通过动作名称查询输入状态，绑定关系可在运行时修改或从配置文件加载
+package input
+
+import "sort"
+
+// 内置动作名称
+const (
+	ActionMoveLeft  = "move_left"
+	ActionMoveRight = "move_right"
+	ActionMoveUp    = "move_up"
+	ActionMoveDown  = "move_down"
+	ActionJump      = "jump"
+	ActionFire      = "fire"
+	ActionAimUp     = "aim_up"
+	ActionAimDown   = "aim_down"
+	ActionMouseAim  = "mouse_aim"
+	// 技能
+	ActionDash        = "dash"
+	ActionGroundPound = "ground_pound"
+	ActionGlide       = "glide"
+	// 切换武器与按槽位选择武器
+	ActionNextWeapon = "next_weapon"
+	ActionPrevWeapon = "prev_weapon"
+	ActionWeapon1    = "weapon_1"
+	ActionWeapon2    = "weapon_2"
+	ActionWeapon3    = "weapon_3"
+	ActionWeapon4    = "weapon_4"
+	// 重新开始关卡
+	ActionRestart = "restart"
+)
+
+// WeaponSlotActions, 按槽位选择武器的动作名称，下标即武器槽位
+var WeaponSlotActions = []string{ActionWeapon1, ActionWeapon2, ActionWeapon3, ActionWeapon4}
+
+// 内置轴名称
+const (
+	AxisMoveX = "move_x"
+	AxisAimY  = "aim_y"
+)
+
+// Axis, 轴对象，由负方向与正方向两个动作组成
+type Axis struct {
+	Negative string `json:"negative"`
+	Positive string `json:"positive"`
+}
+
+// actionState, 动作状态对象，记录动作当前与上次更新时的按下状态及持续按下时长
+type actionState struct {
+	down    bool
+	wasDown bool
+	value   float32
+	held    float64
+}
+
+// Manager, 输入管理对象，维护动作绑定关系与各动作状态
+type Manager struct {
+	bindings map[string][]Binding
+	axes     map[string]Axis
+	states   map[string]*actionState
+	keys     [KeyLast + 1]bool
+	// 两次更新之间按下过的按键，避免在一次更新间隔内完成的点按被遗漏
+	keyHits [KeyLast + 1]bool
+
+	// 鼠标光标位置、按键状态与滚轮偏移
+	mouseX, mouseY                 float32
+	mouseButtons                   [MouseButtonLast + 1]bool
+	mouseHits                      [MouseButtonLast + 1]bool
+	scrollX, scrollY               float32
+	pendingScrollX, pendingScrollY float32
+
+	// 手柄输入源、已连接手柄与手柄映射数据库
+	joysticks       JoystickSource
+	gamepads        [MaxJoysticks]*Gamepad
+	gamepadMappings map[string]*GamepadMapping
+	// 摇杆与扳机死区
+	StickDeadzone   float32
+	TriggerDeadzone float32
+	// 模拟输入视为按下的强度阈值
+	PressThreshold float32
+	// 手柄连接与断开回调
+	OnGamepadConnected    func(pad *Gamepad)
+	OnGamepadDisconnected func(pad *Gamepad)
+
+	// 输入录制与回放
+	recorder FrameSink
+	playback FrameSource
+}
+
+// NewManager, Manager 类实例初始化函数
+// 返回值:
+//     Manager 类指针，不含任何绑定
+func NewManager() *Manager {
+	return &Manager{
+		bindings: make(map[string][]Binding),
+		axes:     make(map[string]Axis),
+		states:   make(map[string]*actionState),
+
+		gamepadMappings: make(map[string]*GamepadMapping),
+		StickDeadzone:   0.25,
+		TriggerDeadzone: 0.1,
+		PressThreshold:  0.5,
+	}
+}
+
+// NewDefaultManager, Manager 类默认实例初始化函数，包含内置动作的默认键盘绑定
+// 返回值:
+//     Manager 类指针
+func NewDefaultManager() *Manager {
+	m := NewManager()
+	m.Bind(ActionMoveLeft, KeyBinding(KeyLeft), KeyBinding(KeyA), PadBinding(GamepadDpadLeft), AxisBinding(GamepadLeftX, false))
+	m.Bind(ActionMoveRight, KeyBinding(KeyRight), KeyBinding(KeyD), PadBinding(GamepadDpadRight), AxisBinding(GamepadLeftX, true))
+	m.Bind(ActionMoveUp, KeyBinding(KeyUp), KeyBinding(KeyW), PadBinding(GamepadDpadUp), AxisBinding(GamepadLeftY, false))
+	m.Bind(ActionMoveDown, KeyBinding(KeyDown), KeyBinding(KeyS), PadBinding(GamepadDpadDown), AxisBinding(GamepadLeftY, true))
+	m.Bind(ActionJump, KeyBinding(KeyUp), KeyBinding(KeyW), PadBinding(GamepadA))
+	m.Bind(ActionFire, KeyBinding(KeyJ), KeyBinding(KeySpace), PadBinding(GamepadX), AxisBinding(GamepadRightTrigger, true), MouseBinding(MouseLeft))
+	m.Bind(ActionAimUp, KeyBinding(KeyUp), KeyBinding(KeyW), PadBinding(GamepadDpadUp), AxisBinding(GamepadLeftY, false))
+	m.Bind(ActionAimDown, KeyBinding(KeyDown), KeyBinding(KeyS), PadBinding(GamepadDpadDown), AxisBinding(GamepadLeftY, true))
+	m.Bind(ActionMouseAim, MouseBinding(MouseLeft), MouseBinding(MouseRight))
+	m.Bind(ActionDash, KeyBinding(KeyK), KeyBinding(KeyLeftShift), PadBinding(GamepadB))
+	m.Bind(ActionGroundPound, KeyBinding(KeyL), PadBinding(GamepadY))
+	m.Bind(ActionGlide, KeyBinding(KeyZ), AxisBinding(GamepadLeftTrigger, true))
+	m.Bind(ActionNextWeapon, KeyBinding(KeyE), PadBinding(GamepadRightBumper))
+	m.Bind(ActionPrevWeapon, KeyBinding(KeyQ), PadBinding(GamepadLeftBumper))
+	for i, action := range WeaponSlotActions {
+		m.Bind(action, KeyBinding(Key1+Key(i)))
+	}
+	m.Bind(ActionRestart, KeyBinding(KeyR), PadBinding(GamepadBack))
+	m.BindAxis(AxisMoveX, ActionMoveLeft, ActionMoveRight)
+	m.BindAxis(AxisAimY, ActionAimUp, ActionAimDown)
+	return m
+}
+
+// SetKeyDown, Manager 类设置键盘按键按下的方法，由窗口层按键回调调用
+// 参数:
+//     key: 键盘按键编码
+func (m *Manager) SetKeyDown(key Key) {
+	if key < 0 || key > KeyLast {
+		return
+	}
+	m.keys[key] = true
+	m.keyHits[key] = true
+}
+
+// ReleaseKey, Manager 类设置键盘按键释放的方法，由窗口层按键回调调用
+// 参数:
+//     key: 键盘按键编码
+func (m *Manager) ReleaseKey(key Key) {
+	if key < 0 || key > KeyLast {
+		return
+	}
+	m.keys[key] = false
+}
+
+// Bind, Manager 类为动作追加绑定的方法
+// 参数:
+//     action: 动作名称
+//     bindings: Binding 类列表
+func (m *Manager) Bind(action string, bindings ...Binding) {
+	for _, b := range bindings {
+		if !m.isBound(action, b) {
+			m.bindings[action] = append(m.bindings[action], b)
+		}
+	}
+	if _, ok := m.states[action]; !ok {
+		m.states[action] = &actionState{}
+	}
+}
+
+// Unbind, Manager 类移除动作绑定的方法
+// 参数:
+//     action: 动作名称
+//     bindings: Binding 类列表，为空时移除该动作的全部绑定
+func (m *Manager) Unbind(action string, bindings ...Binding) {
+	if len(bindings) == 0 {
+		m.bindings[action] = nil
+		return
+	}
+	for _, b := range bindings {
+		list := m.bindings[action]
+		for i := len(list) - 1; i >= 0; i-- {
+			if list[i] == b {
+				list = append(list[:i], list[i+1:]...)
+			}
+		}
+		m.bindings[action] = list
+	}
+}
+
+// Rebind, Manager 类替换动作全部绑定的方法，用于运行时修改按键设置
+// 参数:
+//     action: 动作名称
+//     bindings: Binding 类列表
+func (m *Manager) Rebind(action string, bindings ...Binding) {
+	m.Unbind(action)
+	m.Bind(action, bindings...)
+}
+
+// Bindings, Manager 类获取动作绑定的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     Binding 类切片
+func (m *Manager) Bindings(action string) []Binding {
+	bindings := make([]Binding, len(m.bindings[action]))
+	copy(bindings, m.bindings[action])
+	return bindings
+}
+
+// Actions, Manager 类获取全部动作名称的方法
+// 返回值:
+//     string 类型切片，按名称排序
+func (m *Manager) Actions() []string {
+	actions := make([]string, 0, len(m.states))
+	for action := range m.states {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// BindAxis, Manager 类定义轴的方法
+// 参数:
+//     name: 轴名称
+//     negative, positive: 负方向与正方向的动作名称
+func (m *Manager) BindAxis(name, negative, positive string) {
+	m.axes[name] = Axis{Negative: negative, Positive: positive}
+}
+
+// Update, Manager 类更新动作状态的方法，应在每次逻辑更新开始时调用
+// 参数:
+//     delta: 与上次更新的时延
+func (m *Manager) Update(delta float64) {
+	frame, playing := m.nextPlaybackFrame()
+	if playing {
+		m.mouseX, m.mouseY = frame.MouseX, frame.MouseY
+		m.scrollX, m.scrollY = frame.ScrollX, frame.ScrollY
+		m.pendingScrollX, m.pendingScrollY = 0, 0
+	} else {
+		m.RefreshGamepads()
+		m.updateMouse()
+	}
+
+	for action, state := range m.states {
+		state.wasDown = state.down
+		if playing {
+			state.value = frame.Values[action]
+		} else {
+			state.value = 0
+			for _, b := range m.bindings[action] {
+				if v := m.bindingValue(b); v > state.value {
+					state.value = v
+				}
+			}
+		}
+		state.down = state.value > 0 && state.value >= m.PressThreshold
+
+		if state.down && state.wasDown {
+			state.held += delta
+		} else {
+			state.held = 0
+		}
+	}
+	m.keyHits = [KeyLast + 1]bool{}
+	m.mouseHits = [MouseButtonLast + 1]bool{}
+
+	if m.recorder != nil {
+		m.recorder.RecordFrame(m.Frame())
+	}
+}
+
+// Pressed, Manager 类判断动作是否处于按下状态的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     bool 类型
+func (m *Manager) Pressed(action string) bool {
+	if state, ok := m.states[action]; ok {
+		return state.down
+	}
+	return false
+}
+
+// JustPressed, Manager 类判断动作是否在本次更新中刚被按下的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     bool 类型
+func (m *Manager) JustPressed(action string) bool {
+	if state, ok := m.states[action]; ok {
+		return state.down && !state.wasDown
+	}
+	return false
+}
+
+// JustReleased, Manager 类判断动作是否在本次更新中刚被释放的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     bool 类型
+func (m *Manager) JustReleased(action string) bool {
+	if state, ok := m.states[action]; ok {
+		return !state.down && state.wasDown
+	}
+	return false
+}
+
+// HeldDuration, Manager 类获取动作持续按下时长的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     float64 类型，单位为秒，未按下时为 0
+func (m *Manager) HeldDuration(action string) float64 {
+	if state, ok := m.states[action]; ok {
+		return state.held
+	}
+	return 0
+}
+
+// Value, Manager 类获取动作强度的方法
+// 参数:
+//     action: 动作名称
+// 返回值:
+//     float32 类型，取值 [0, 1]，数字按键按下时为 1，模拟轴为死区处理后的数值
+func (m *Manager) Value(action string) float32 {
+	if state, ok := m.states[action]; ok {
+		return state.value
+	}
+	return 0
+}
+
+// Axis, Manager 类获取轴数值的方法
+// 参数:
+//     name: 轴名称
+// 返回值:
+//     float32 类型，取值 [-1, 1]，为正方向与负方向动作强度之差
+func (m *Manager) Axis(name string) float32 {
+	axis, ok := m.axes[name]
+	if !ok {
+		return 0
+	}
+	return m.Value(axis.Positive) - m.Value(axis.Negative)
+}
+
+// isBound, Manager 类判断动作是否已含有指定绑定的包内方法
+// 参数:
+//     action: 动作名称
+//     b: Binding 类
+// 返回值:
+//     bool 类型
+func (m *Manager) isBound(action string, b Binding) bool {
+	for _, bound := range m.bindings[action] {
+		if bound == b {
+			return true
+		}
+	}
+	return false
+}
+
+// bindingValue, Manager 类获取绑定当前输入强度的包内方法
+// 参数:
+//     b: Binding 类
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func (m *Manager) bindingValue(b Binding) float32 {
+	switch b.Device {
+	case Keyboard:
+		if b.Code >= 0 && Key(b.Code) <= KeyLast && (m.keys[b.Code] || m.keyHits[b.Code]) {
+			return 1
+		}
+	case MouseDevice:
+		if b.Code >= 0 && MouseButton(b.Code) <= MouseButtonLast && (m.mouseButtons[b.Code] || m.mouseHits[b.Code]) {
+			return 1
+		}
+	case GamepadButtonDevice:
+		return m.gamepadButtonValue(GamepadButton(b.Code))
+	case GamepadAxisDevice:
+		return m.gamepadAxisValue(GamepadAxis(b.Code/2), b.Code%2 == 1)
+	}
+	return 0
+}
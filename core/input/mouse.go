@@ -0,0 +1,113 @@
+package input
+
+import "fmt"
+
+// MouseButton, 鼠标按键编码，取值与 GLFW 鼠标按键编码一致
+type MouseButton int
+
+const (
+	MouseLeft       MouseButton = 0
+	MouseRight      MouseButton = 1
+	MouseMiddle     MouseButton = 2
+	MouseButtonLast MouseButton = 7
+)
+
+var mouseButtonNames = map[string]MouseButton{
+	"Left":   MouseLeft,
+	"Right":  MouseRight,
+	"Middle": MouseMiddle,
+}
+
+// ParseMouseButton, 根据名称获取鼠标按键编码的函数
+// 参数:
+//     name: 按键名称，如 "Left"、"Right"、"Middle"，其余按键使用 "Button4" 至 "Button8"
+// 返回值:
+//     MouseButton 类型
+//     error 类型，名称无法识别时返回错误
+func ParseMouseButton(name string) (MouseButton, error) {
+	if b, ok := mouseButtonNames[name]; ok {
+		return b, nil
+	}
+	for b := MouseMiddle + 1; b <= MouseButtonLast; b++ {
+		if b.String() == name {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown mouse button %q", name)
+}
+
+// String, MouseButton 类获取按键名称的方法
+func (b MouseButton) String() string {
+	for name, button := range mouseButtonNames {
+		if button == b {
+			return name
+		}
+	}
+	return fmt.Sprintf("Button%d", int(b)+1)
+}
+
+// MouseBinding, 鼠标按键绑定初始化函数
+// 参数:
+//     button: 鼠标按键编码
+// 返回值:
+//     Binding 类
+func MouseBinding(button MouseButton) Binding {
+	return Binding{Device: MouseDevice, Code: int(button)}
+}
+
+// SetMousePosition, Manager 类设置鼠标光标位置的方法，由窗口层光标回调调用
+// 参数:
+//     x, y: 光标在窗口中的像素坐标，原点为窗口左上角
+func (m *Manager) SetMousePosition(x, y float64) {
+	m.mouseX = float32(x)
+	m.mouseY = float32(y)
+}
+
+// SetMouseButtonDown, Manager 类设置鼠标按键按下的方法，由窗口层鼠标按键回调调用
+// 参数:
+//     button: 鼠标按键编码
+func (m *Manager) SetMouseButtonDown(button MouseButton) {
+	if button < 0 || button > MouseButtonLast {
+		return
+	}
+	m.mouseButtons[button] = true
+	m.mouseHits[button] = true
+}
+
+// ReleaseMouseButton, Manager 类设置鼠标按键释放的方法，由窗口层鼠标按键回调调用
+// 参数:
+//     button: 鼠标按键编码
+func (m *Manager) ReleaseMouseButton(button MouseButton) {
+	if button < 0 || button > MouseButtonLast {
+		return
+	}
+	m.mouseButtons[button] = false
+}
+
+// AddScroll, Manager 类累加滚轮偏移的方法，由窗口层滚轮回调调用
+// 参数:
+//     dx, dy: 水平与垂直方向滚动偏移
+func (m *Manager) AddScroll(dx, dy float64) {
+	m.pendingScrollX += float32(dx)
+	m.pendingScrollY += float32(dy)
+}
+
+// MousePosition, Manager 类获取鼠标光标位置的方法
+// 返回值:
+//     float32, float32 类型，光标在窗口中的像素坐标
+func (m *Manager) MousePosition() (float32, float32) {
+	return m.mouseX, m.mouseY
+}
+
+// ScrollDelta, Manager 类获取本次更新内滚轮偏移的方法
+// 返回值:
+//     float32, float32 类型，水平与垂直方向滚动偏移，向上滚动时垂直偏移为正
+func (m *Manager) ScrollDelta() (float32, float32) {
+	return m.scrollX, m.scrollY
+}
+
+// updateMouse, Manager 类在每次更新时结算滚轮偏移的包内方法
+func (m *Manager) updateMouse() {
+	m.scrollX, m.scrollY = m.pendingScrollX, m.pendingScrollY
+	m.pendingScrollX, m.pendingScrollY = 0, 0
+}
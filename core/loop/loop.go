@@ -0,0 +1,111 @@
+// loop 包，该包定义了固定时间步长的游戏主循环，使游戏逻辑更新与显示帧率解耦，
+// 并为渲染提供插值系数，以便在前后两次逻辑状态之间平滑渲染
+package loop
+
+// Updater, 游戏逻辑更新接口对象
+type Updater interface {
+	Update(delta float64)
+}
+
+// 默认逻辑更新步长与单帧最大计入时长，单位为秒
+const (
+	DefaultStep         = 1.0 / 60
+	DefaultMaxFrameTime = 0.25
+)
+
+// Loop, 固定步长循环对象，通过累加器将帧时间切分为若干固定步长的逻辑更新
+type Loop struct {
+	// 逻辑更新固定步长，单位为秒，不大于 0 时使用 DefaultStep
+	Step float64
+	// 单帧最大计入时长，用于防止卡顿后逻辑更新次数不断累积（spiral of death），不大于 0 时不限
+	MaxFrameTime float64
+	accumulator  float64
+	lastTime     float64
+	started      bool
+	ticks        uint64
+}
+
+// NewLoop, Loop 类实例初始化函数
+// 参数:
+//     step: 逻辑更新固定步长，单位为秒，不大于 0 时使用 DefaultStep
+//     maxFrameTime: 单帧最大计入时长，单位为秒，不大于 0 时不限
+// 返回值:
+//     Loop 类指针
+func NewLoop(step, maxFrameTime float64) *Loop {
+	if step <= 0 {
+		step = DefaultStep
+	}
+	return &Loop{
+		Step:         step,
+		MaxFrameTime: maxFrameTime,
+	}
+}
+
+// NewDefaultLoop, Loop 类默认实例初始化函数，以 60Hz 更新逻辑，单帧最多计入 0.25 秒
+// 返回值:
+//     Loop 类指针
+func NewDefaultLoop() *Loop {
+	return NewLoop(DefaultStep, DefaultMaxFrameTime)
+}
+
+// Tick, Loop 类推进一帧的方法，按累计时长执行若干次固定步长的逻辑更新
+// 参数:
+//     now: 当前时间，单位为秒
+//     game: Updater 接口对象
+// 返回值:
+//     float64 类型，渲染插值系数，取值 [0, 1)，为剩余累计时长占步长的比例
+func (l *Loop) Tick(now float64, game Updater) float64 {
+	if !l.started {
+		l.started = true
+		l.lastTime = now
+	}
+
+	frameTime := now - l.lastTime
+	l.lastTime = now
+	if l.MaxFrameTime > 0 && frameTime > l.MaxFrameTime {
+		frameTime = l.MaxFrameTime
+	}
+	if frameTime < 0 {
+		frameTime = 0
+	}
+
+	step := l.step()
+	l.accumulator += frameTime
+	for l.accumulator >= step {
+		game.Update(step)
+		l.accumulator -= step
+		l.ticks++
+	}
+
+	return l.Alpha()
+}
+
+// Alpha, Loop 类获取当前渲染插值系数的方法
+// 返回值:
+//     float64 类型，取值 [0, 1)
+func (l *Loop) Alpha() float64 {
+	return l.accumulator / l.step()
+}
+
+// step, Loop 类获取实际逻辑更新步长的包内方法
+// 返回值:
+//     float64 类型，Step 不大于 0 时为 DefaultStep
+func (l *Loop) step() float64 {
+	if l.Step <= 0 {
+		return DefaultStep
+	}
+	return l.Step
+}
+
+// Ticks, Loop 类获取已执行逻辑更新次数的方法
+// 返回值:
+//     uint64 类型
+func (l *Loop) Ticks() uint64 {
+	return l.ticks
+}
+
+// Reset, Loop 类重置累加器的方法，下一次 Tick 将重新计时
+func (l *Loop) Reset() {
+	l.accumulator = 0
+	l.started = false
+}
@@ -0,0 +1,87 @@
+package loop
+
+import "testing"
+
+// counter, 记录逻辑更新次数与步长的测试 Updater
+type counter struct {
+	updates int
+	deltas  []float64
+}
+
+func (c *counter) Update(delta float64) {
+	c.updates++
+	c.deltas = append(c.deltas, delta)
+}
+
+func TestTick(t *testing.T) {
+	tests := []struct {
+		name        string
+		step, max   float64
+		frames      []float64
+		wantUpdates []int
+		wantAlpha   float64
+		wantTicks   uint64
+		wantDelta   float64
+	}{
+		{"first tick only starts the clock", 0.25, 1, []float64{5}, []int{0}, 0, 0, 0.25},
+		{"whole steps", 0.25, 1, []float64{0, 0.5, 1.25}, []int{0, 2, 3}, 0, 5, 0.25},
+		{"carry over", 0.25, 1, []float64{0, 0.375, 0.875}, []int{0, 1, 2}, 0.5, 3, 0.25},
+		{"spiral of death clamp", 0.25, 0.5, []float64{0, 10, 10.125}, []int{0, 2, 0}, 0.5, 2, 0.25},
+		{"no frame time limit", 0.25, 0, []float64{0, 2.625}, []int{0, 10}, 0.5, 10, 0.25},
+		{"clock going backwards", 0.25, 1, []float64{1, 0.5, 0.625}, []int{0, 0, 0}, 0.5, 0, 0.25},
+		{"non-positive step uses default", 0, 1, []float64{0, 0.505}, []int{0, 30}, 0.3, 30, DefaultStep},
+	}
+	for _, tt := range tests {
+		l := NewLoop(tt.step, tt.max)
+		c := &counter{}
+		var alpha float64
+		for i, now := range tt.frames {
+			before := c.updates
+			alpha = l.Tick(now, c)
+			if got := c.updates - before; got != tt.wantUpdates[i] {
+				t.Errorf("%s: frame %d ran %d updates, want %d", tt.name, i, got, tt.wantUpdates[i])
+			}
+			if alpha < 0 || alpha >= 1 {
+				t.Errorf("%s: frame %d alpha %v out of [0, 1)", tt.name, i, alpha)
+			}
+		}
+		if d := alpha - tt.wantAlpha; d > 1e-6 || d < -1e-6 {
+			t.Errorf("%s: alpha %v, want %v", tt.name, alpha, tt.wantAlpha)
+		}
+		if l.Ticks() != tt.wantTicks {
+			t.Errorf("%s: %d ticks, want %d", tt.name, l.Ticks(), tt.wantTicks)
+		}
+		for _, d := range c.deltas {
+			if d != tt.wantDelta {
+				t.Errorf("%s: update delta %v, want %v", tt.name, d, tt.wantDelta)
+				break
+			}
+		}
+	}
+}
+
+func TestStepChangedAfterCreate(t *testing.T) {
+	l := NewDefaultLoop()
+	l.Step = 0
+	c := &counter{}
+	l.Tick(0, c)
+	if alpha := l.Tick(0.1, c); c.updates != 6 || alpha < 0 || alpha >= 1 {
+		t.Errorf("%d updates, alpha %v with a zero step", c.updates, alpha)
+	}
+}
+
+func TestReset(t *testing.T) {
+	l := NewLoop(0.1, 1)
+	c := &counter{}
+	l.Tick(0, c)
+	l.Tick(0.15, c)
+	l.Reset()
+	if l.Alpha() != 0 {
+		t.Errorf("alpha %v after reset", l.Alpha())
+	}
+	// 重置后首次 Tick 重新计时，不计入暂停期间
+	l.Tick(100, c)
+	if c.updates != 1 || l.Ticks() != 1 {
+		t.Errorf("%d updates after reset", c.updates)
+	}
+}
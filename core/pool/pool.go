@@ -0,0 +1,103 @@
+// pool 包，该包提供通用对象池：复用投射物、粒子、敌人等频繁创建与销毁的对象，减少内存分配与垃圾回收压力，
+// 对象池记录命中、未命中等指标，便于调整容量
+package pool
+
+// Stats, 对象池指标对象
+type Stats struct {
+	// 从空闲对象中取得对象的次数
+	Hits int
+	// 无空闲对象而新建对象的次数
+	Misses int
+	// 归还对象的次数
+	Releases int
+	// 因空闲对象已达容量上限而丢弃归还对象的次数
+	Drops int
+}
+
+// Pool, 对象池对象，非并发安全，应在逻辑更新所在的协程中使用
+type Pool struct {
+	// 新建对象函数
+	New func() interface{}
+	// 重置对象函数，在对象归还时调用，可为 nil
+	Reset func(obj interface{})
+	// 空闲对象容量上限，不大于 0 时不限
+	Cap   int
+	free  []interface{}
+	stats Stats
+}
+
+// NewPool, Pool 类实例初始化函数
+// 参数:
+//     newFn: 新建对象函数
+//     reset: 重置对象函数，可为 nil
+//     capacity: 空闲对象容量上限，不大于 0 时不限
+// 返回值:
+//     Pool 类指针
+func NewPool(newFn func() interface{}, reset func(obj interface{}), capacity int) *Pool {
+	p := &Pool{New: newFn, Reset: reset, Cap: capacity}
+	if capacity > 0 {
+		p.free = make([]interface{}, 0, capacity)
+	}
+	return p
+}
+
+// Acquire, Pool 类取得对象的方法，优先复用空闲对象，无空闲对象时新建
+// 返回值:
+//     interface{} 类型
+func (p *Pool) Acquire() interface{} {
+	if n := len(p.free); n > 0 {
+		obj := p.free[n-1]
+		p.free[n-1] = nil
+		p.free = p.free[:n-1]
+		p.stats.Hits++
+		return obj
+	}
+	p.stats.Misses++
+	return p.New()
+}
+
+// Release, Pool 类归还对象的方法，对象重置后加入空闲对象，空闲对象已达容量上限时丢弃
+// 参数:
+//     obj: 归还的对象，不应重复归还
+func (p *Pool) Release(obj interface{}) {
+	if obj == nil {
+		return
+	}
+	p.stats.Releases++
+	if p.Reset != nil {
+		p.Reset(obj)
+	}
+	if p.Cap > 0 && len(p.free) >= p.Cap {
+		p.stats.Drops++
+		return
+	}
+	p.free = append(p.free, obj)
+}
+
+// Prewarm, Pool 类预先新建空闲对象的方法，不超过容量上限，不计入指标
+// 参数:
+//     n: 预先新建的对象数
+func (p *Pool) Prewarm(n int) {
+	for i := 0; i < n && (p.Cap <= 0 || len(p.free) < p.Cap); i++ {
+		p.free = append(p.free, p.New())
+	}
+}
+
+// Len, Pool 类获取空闲对象数的方法
+// 返回值:
+//     int 类型
+func (p *Pool) Len() int {
+	return len(p.free)
+}
+
+// Stats, Pool 类获取指标的方法
+// 返回值:
+//     Stats 类
+func (p *Pool) Stats() Stats {
+	return p.stats
+}
+
+// ResetStats, Pool 类清零指标的方法
+func (p *Pool) ResetStats() {
+	p.stats = Stats{}
+}
@@ -0,0 +1,92 @@
+package pool
+
+import "testing"
+
+type object struct {
+	n int
+}
+
+func newObjectPool(capacity int, created *int) *Pool {
+	return NewPool(func() interface{} {
+		*created++
+		return &object{}
+	}, func(obj interface{}) { obj.(*object).n = 0 }, capacity)
+}
+
+func TestPrewarmAndAcquire(t *testing.T) {
+	created := 0
+	p := newObjectPool(4, &created)
+	p.Prewarm(6)
+	if created != 4 || p.Len() != 4 {
+		t.Fatalf("prewarm created %d objects, %d free, want 4", created, p.Len())
+	}
+	if st := p.Stats(); st != (Stats{}) {
+		t.Errorf("prewarm counted in stats: %+v", st)
+	}
+
+	for i := 0; i < 6; i++ {
+		p.Acquire()
+	}
+	want := Stats{Hits: 4, Misses: 2}
+	if st := p.Stats(); st != want || created != 6 || p.Len() != 0 {
+		t.Errorf("after acquire: %+v, %d created, %d free, want %+v", st, created, p.Len(), want)
+	}
+
+	p.ResetStats()
+	if st := p.Stats(); st != (Stats{}) {
+		t.Errorf("after reset stats: %+v", st)
+	}
+}
+
+func TestReleaseCap(t *testing.T) {
+	tests := []struct {
+		cap, release int
+		free, drops  int
+	}{
+		{2, 1, 1, 0},
+		{2, 2, 2, 0},
+		{2, 5, 2, 3},
+		{0, 5, 5, 0},
+	}
+	for _, tt := range tests {
+		created := 0
+		p := newObjectPool(tt.cap, &created)
+		for i := 0; i < tt.release; i++ {
+			p.Release(&object{})
+		}
+		p.Release(nil)
+		want := Stats{Releases: tt.release, Drops: tt.drops}
+		if st := p.Stats(); st != want || p.Len() != tt.free {
+			t.Errorf("cap %d, %d released: %+v, %d free, want %+v, %d free", tt.cap, tt.release, st, p.Len(), want, tt.free)
+		}
+	}
+}
+
+func TestReleaseResets(t *testing.T) {
+	created := 0
+	p := newObjectPool(1, &created)
+	kept, dropped := &object{n: 1}, &object{n: 2}
+	p.Release(kept)
+	p.Release(dropped)
+	if kept.n != 0 || dropped.n != 0 {
+		t.Errorf("reset not run on release: kept %d, dropped %d", kept.n, dropped.n)
+	}
+	if obj := p.Acquire(); obj != kept {
+		t.Errorf("acquired %p, want the released object %p", obj, kept)
+	}
+	if created != 0 {
+		t.Errorf("%d objects created, want reuse", created)
+	}
+}
+
+func BenchmarkAcquireRelease(b *testing.B) {
+	p := NewPool(func() interface{} { return &object{} }, func(obj interface{}) { obj.(*object).n = 0 }, 16)
+	p.Prewarm(16)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obj := p.Acquire().(*object)
+		obj.n = i
+		p.Release(obj)
+	}
+}
@@ -19,7 +19,23 @@ func NewSpriteRenderer(shader *resource.Shader) *SpriteRenderer {
 	return &spriteRenderer
 }
 
+// fullUV, 完整纹理的纹理坐标变换，偏移为 0，缩放为 1
+var fullUV = mgl32.Vec4{0, 0, 1, 1}
+
 func (sr *SpriteRenderer) DrawSprite(texture *resource.Texture2D, position *mgl32.Vec2, size *mgl32.Vec2, rotate float32, color *mgl32.Vec3, isReverseX bool) {
+	sr.DrawSpriteUV(texture, position, size, rotate, color, isReverseX, fullUV)
+}
+
+// DrawSpriteUV, SpriteRenderer 类按指定纹理坐标变换渲染精灵的方法，配合纹理的 REPEAT 环绕方式可实现平铺与滚动
+// 参数:
+//     texture: 渲染纹理
+//     position: 渲染位置
+//     size: 渲染尺寸
+//     rotate: 旋转弧度
+//     color: 颜色
+//     isReverseX: 是否水平翻转
+//     uv: 纹理坐标变换，前两个分量为偏移，后两个分量为缩放
+func (sr *SpriteRenderer) DrawSpriteUV(texture *resource.Texture2D, position *mgl32.Vec2, size *mgl32.Vec2, rotate float32, color *mgl32.Vec3, isReverseX bool, uv mgl32.Vec4) {
 	model := mgl32.Translate3D(position[0], position[1], 0).Mul4(mgl32.Translate3D(0.5*size[0], 0.5*size[1], 0))
 	model = model.Mul4(mgl32.HomogRotate3D(rotate, mgl32.Vec3{0, 0, 1}))
 	model = model.Mul4(mgl32.Translate3D(-0.5*size[0], -0.5*size[1], 0))
@@ -32,6 +48,7 @@ func (sr *SpriteRenderer) DrawSprite(texture *resource.Texture2D, position *mgl3
 		sr.shader.SetInt("reverseX", 1)
 	}
 	sr.shader.SetVector3f("spriteColor", *color)
+	sr.shader.SetVector4f("uvRect", uv)
 	texture.Use()
 
 	gl.BindVertexArray(sr.vao)
@@ -0,0 +1,48 @@
+package render
+
+// MaxLayers, 渲染层数量上限，受 LayerMask 位宽限制
+const MaxLayers = 32
+
+// 内置渲染层序号，序号仅作标识，渲染顺序由场景的渲染层列表决定；
+// 形状对象默认位于第 0 层，即地形层
+const (
+	LayerTerrain = iota
+	LayerBackground
+	LayerActors
+	LayerProjectiles
+	LayerForeground
+	LayerUI
+)
+
+// LayerMask, 渲染层掩码，第 n 位为 1 表示包含第 n 层
+type LayerMask uint32
+
+// AllLayers, 包含全部渲染层的掩码
+const AllLayers = ^LayerMask(0)
+
+// LayerMaskOf, 根据渲染层序号生成渲染层掩码的函数
+// 参数:
+//     layers: 渲染层序号列表，取值 [0, MaxLayers)，超出范围的序号将被忽略
+// 返回值:
+//     LayerMask 类型
+func LayerMaskOf(layers ...int) LayerMask {
+	var m LayerMask
+	for _, layer := range layers {
+		if layer >= 0 && layer < MaxLayers {
+			m |= 1 << uint(layer)
+		}
+	}
+	return m
+}
+
+// Has, LayerMask 类判断是否包含指定渲染层的方法
+// 参数:
+//     layer: 渲染层序号
+// 返回值:
+//     bool 类型
+func (m LayerMask) Has(layer int) bool {
+	if layer < 0 || layer >= MaxLayers {
+		return false
+	}
+	return m&(1<<uint(layer)) != 0
+}
@@ -0,0 +1,12 @@
+package render
+
+import "github.com/go-gl/gl/v4.1-core/gl"
+
+// SetViewport, 设置渲染视口的函数
+// 参数:
+//     x, y: 视口左上角窗口像素坐标
+//     w, h: 视口尺寸
+//     windowH: 窗口高度，用于换算为以窗口左下角为原点的 OpenGL 视口坐标
+func SetViewport(x, y, w, h, windowH float32) {
+	gl.Viewport(int32(x), int32(windowH-y-h), int32(w), int32(h))
+}
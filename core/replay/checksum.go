@@ -0,0 +1,40 @@
+package replay
+
+import (
+	"encoding/binary"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"hash/fnv"
+	"math"
+)
+
+// Checksum, 计算场景空间状态校验值的函数，涵盖各形状对象的坐标、速度与标签，
+// 用于验证回放结束时的空间状态与录制时是否一致
+// 参数:
+//     sp: resolv.Space 类指针
+// 返回值:
+//     uint64 类型，校验值
+func Checksum(sp *resolv.Space) uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 4)
+	writeUint32 := func(v uint32) {
+		binary.LittleEndian.PutUint32(buf, v)
+		h.Write(buf)
+	}
+
+	for _, shape := range *sp {
+		x, y := shape.GetXY()
+		x2, y2 := shape.GetXY2()
+		spdX, spdY := shape.GetSpd()
+		for _, v := range []int32{x, y, x2, y2} {
+			writeUint32(uint32(v))
+		}
+		writeUint32(math.Float32bits(spdX))
+		writeUint32(math.Float32bits(spdY))
+		for _, tag := range shape.GetTags() {
+			h.Write([]byte(tag))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{0xff})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,83 @@
+package replay
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/loop"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+)
+
+// Playback, 回放会话对象， loop.Updater 接口的实现，包装游戏对象并在回放完毕时校验空间状态
+type Playback struct {
+	rec      *Recording
+	player   *Player
+	game     loop.Updater
+	space    *resolv.Space
+	finished bool
+	// OnFinish, 全部输入快照回放完毕后的回调，参数为空间状态是否与录制时一致（录像不含校验值时恒为 true）
+	OnFinish func(match bool)
+}
+
+// NewPlayback, Playback 类实例初始化函数，并令输入管理器开始回放
+// 参数:
+//     rec: Recording 类指针
+//     m: input.Manager 类指针，游戏对象每次更新时读取其动作状态
+//     game: loop.Updater 接口对象，被回放的游戏对象
+//     sp: resolv.Space 类指针，回放结束时用于校验的场景空间
+// 返回值:
+//     Playback 类指针
+func NewPlayback(rec *Recording, m *input.Manager, game loop.Updater, sp *resolv.Space) *Playback {
+	p := &Playback{
+		rec:    rec,
+		player: rec.NewPlayer(),
+		game:   game,
+		space:  sp,
+	}
+	m.SetPlayback(p.player)
+	return p
+}
+
+// Update, Playback 类 loop.Updater.Update(delta float64) 的实现
+// 参数:
+//     delta: 与上次更新的时延
+func (p *Playback) Update(delta float64) {
+	p.game.Update(delta)
+	if !p.finished && p.player.Done() {
+		p.finished = true
+		if p.OnFinish != nil {
+			p.OnFinish(p.Verify())
+		}
+	}
+}
+
+// Finished, Playback 类判断回放是否完毕的方法
+// 返回值:
+//     bool 类型
+func (p *Playback) Finished() bool {
+	return p.finished
+}
+
+// Verify, Playback 类校验当前空间状态与录制结束时是否一致的方法
+// 返回值:
+//     bool 类型，录像不含校验值时恒为 true
+func (p *Playback) Verify() bool {
+	if !p.rec.HasChecksum || p.space == nil {
+		return true
+	}
+	return Checksum(p.space) == p.rec.Checksum
+}
+
+// Run, 以录像的固定步长无渲染地执行全部回放的函数，可在测试中直接调用以验证关卡流程
+// 参数:
+//     rec: Recording 类指针
+//     m: input.Manager 类指针
+//     game: loop.Updater 接口对象
+//     sp: resolv.Space 类指针，用于校验的场景空间
+// 返回值:
+//     bool 类型，回放结束时空间状态是否与录制时一致
+func Run(rec *Recording, m *input.Manager, game loop.Updater, sp *resolv.Space) bool {
+	p := NewPlayback(rec, m, game, sp)
+	for !p.Finished() {
+		p.Update(rec.Step)
+	}
+	return p.Verify()
+}
@@ -0,0 +1,435 @@
+// replay 包，该包提供输入录制与确定性回放功能：录制时记录每次逻辑更新的动作状态，
+// 回放时在固定步长下经由同一输入路径重放，并以空间状态校验值验证回放结果是否一致
+package replay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"io"
+	"math"
+	"os"
+)
+
+// 录像文件格式标识与版本
+const (
+	magic   = "2DRP"
+	version = 1
+)
+
+// 单条记录的标志位
+const (
+	flagAnalog = 1 << iota
+	flagMouse
+	flagScroll
+)
+
+// maxActions, 录像支持的最大动作数量，受动作位掩码宽度限制
+const maxActions = 64
+
+// maxActionName, 录像中动作名称的最大长度
+const maxActionName = 256
+
+// maxRepeat, 录像中单个输入快照的最大连续重复次数，约为 60Hz 下一小时的逻辑更新次数
+const maxRepeat = 60 * 60 * 60
+
+// Recording, 录像对象，包含固定步长、动作名称列表、每次逻辑更新的输入快照及结束时的空间状态校验值
+type Recording struct {
+	Step     float64
+	Actions  []string
+	Frames   []input.Frame
+	Checksum uint64
+	// HasChecksum, 录像是否包含校验值
+	HasChecksum bool
+}
+
+// Recorder, 录制对象， input.FrameSink 接口的实现
+type Recorder struct {
+	rec *Recording
+}
+
+// NewRecorder, Recorder 类实例初始化函数，并开始录制输入管理器的输入快照
+// 参数:
+//     m: input.Manager 类指针
+//     step: 逻辑更新固定步长
+// 返回值:
+//     Recorder 类指针
+func NewRecorder(m *input.Manager, step float64) *Recorder {
+	r := &Recorder{
+		rec: &Recording{
+			Step:    step,
+			Actions: m.Actions(),
+			Frames:  make([]input.Frame, 0),
+		},
+	}
+	m.SetRecorder(r)
+	return r
+}
+
+// RecordFrame, Recorder 类 input.FrameSink.RecordFrame(input.Frame) 的实现
+// 参数:
+//     f: input.Frame 类，输入快照
+func (r *Recorder) RecordFrame(f input.Frame) {
+	r.rec.Frames = append(r.rec.Frames, f)
+}
+
+// Finish, Recorder 类结束录制的方法，记录空间状态校验值
+// 参数:
+//     m: input.Manager 类指针，停止其录制
+//     sp: resolv.Space 类指针，录制结束时的场景空间
+// 返回值:
+//     Recording 类指针
+func (r *Recorder) Finish(m *input.Manager, sp *resolv.Space) *Recording {
+	m.SetRecorder(nil)
+	if sp != nil {
+		r.rec.Checksum = Checksum(sp)
+		r.rec.HasChecksum = true
+	}
+	return r.rec
+}
+
+// Recording, Recorder 类获取当前录像的方法
+// 返回值:
+//     Recording 类指针
+func (r *Recorder) Recording() *Recording {
+	return r.rec
+}
+
+// Player, 回放对象， input.FrameSource 接口的实现
+type Player struct {
+	rec   *Recording
+	index int
+}
+
+// NewPlayer, Recording 类创建回放对象的方法
+// 返回值:
+//     Player 类指针
+func (rec *Recording) NewPlayer() *Player {
+	return &Player{rec: rec}
+}
+
+// NextFrame, Player 类 input.FrameSource.NextFrame() (input.Frame, bool) 的实现
+// 返回值:
+//     input.Frame 类，下一次逻辑更新的输入快照
+//     bool 类型， false 为回放结束
+func (p *Player) NextFrame() (input.Frame, bool) {
+	if p.Done() {
+		return input.Frame{}, false
+	}
+	f := p.rec.Frames[p.index]
+	p.index++
+	return f, true
+}
+
+// Done, Player 类判断全部输入快照是否已回放完毕的方法
+// 返回值:
+//     bool 类型
+func (p *Player) Done() bool {
+	return p.index >= len(p.rec.Frames)
+}
+
+// Save, Recording 类保存录像至文件的方法
+// 参数:
+//     file: 文件路径
+// 返回值:
+//     error 类型
+func (rec *Recording) Save(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := rec.Write(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load, 从文件读取录像的函数
+// 参数:
+//     file: 文件路径
+// 返回值:
+//     Recording 类指针
+//     error 类型
+func Load(file string) (*Recording, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(bufio.NewReader(f))
+}
+
+// Write, Recording 类以紧凑二进制格式写出录像的方法，连续相同的输入快照合并为一条记录
+// 参数:
+//     w: io.Writer 接口对象
+// 返回值:
+//     error 类型，动作数量超出上限或写入失败时返回错误
+func (rec *Recording) Write(w io.Writer) error {
+	if len(rec.Actions) > maxActions {
+		return fmt.Errorf("replay supports at most %d actions, got %d", maxActions, len(rec.Actions))
+	}
+	e := &encoder{w: w}
+	e.bytes([]byte(magic))
+	e.bytes([]byte{version})
+	e.float64(rec.Step)
+	e.uvarint(uint64(len(rec.Actions)))
+	for _, action := range rec.Actions {
+		e.uvarint(uint64(len(action)))
+		e.bytes([]byte(action))
+	}
+
+	// 合并连续相同的输入快照，单条记录的重复次数不超过 maxRepeat
+	type run struct {
+		frame  input.Frame
+		repeat uint64
+	}
+	runs := make([]run, 0)
+	for _, f := range rec.Frames {
+		if n := len(runs); n > 0 && runs[n-1].repeat < maxRepeat && framesEqual(runs[n-1].frame, f) {
+			runs[n-1].repeat++
+			continue
+		}
+		runs = append(runs, run{f, 1})
+	}
+
+	e.uvarint(uint64(len(runs)))
+	var prevX, prevY float32
+	for _, r := range runs {
+		e.uvarint(r.repeat)
+		rec.encodeFrame(e, r.frame, prevX, prevY)
+		prevX, prevY = r.frame.MouseX, r.frame.MouseY
+	}
+
+	if rec.HasChecksum {
+		e.bytes([]byte{1})
+		e.uint64(rec.Checksum)
+	} else {
+		e.bytes([]byte{0})
+	}
+	return e.err
+}
+
+// Read, 读取紧凑二进制格式录像的函数
+// 参数:
+//     r: io.Reader 接口对象
+// 返回值:
+//     Recording 类指针
+//     error 类型，格式错误或读取失败时返回错误
+func Read(r io.Reader) (*Recording, error) {
+	d := &decoder{r: bufio.NewReader(r)}
+	if string(d.bytes(len(magic))) != magic {
+		return nil, errors.New("not a replay file")
+	}
+	if v := d.bytes(1); d.err == nil && v[0] != version {
+		return nil, fmt.Errorf("unsupported replay version %d", v[0])
+	}
+
+	rec := &Recording{Step: d.float64()}
+	n := d.uvarint()
+	if n > maxActions {
+		return nil, fmt.Errorf("replay supports at most %d actions, got %d", maxActions, n)
+	}
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		length := d.uvarint()
+		if length > maxActionName {
+			return nil, fmt.Errorf("replay action name too long: %d", length)
+		}
+		rec.Actions = append(rec.Actions, string(d.bytes(int(length))))
+	}
+
+	runs := d.uvarint()
+	rec.Frames = make([]input.Frame, 0)
+	var prevX, prevY float32
+	for i := uint64(0); i < runs && d.err == nil; i++ {
+		repeat := d.uvarint()
+		if repeat > maxRepeat {
+			return nil, fmt.Errorf("replay frame repeated too many times: %d", repeat)
+		}
+		f := rec.decodeFrame(d, prevX, prevY)
+		prevX, prevY = f.MouseX, f.MouseY
+		for j := uint64(0); j < repeat; j++ {
+			rec.Frames = append(rec.Frames, f)
+		}
+	}
+
+	if flag := d.bytes(1); d.err == nil && flag[0] == 1 {
+		rec.Checksum = d.uint64()
+		rec.HasChecksum = true
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return rec, nil
+}
+
+// encodeFrame, Recording 类写出单个输入快照的包内方法
+// 按下（强度为 1）的动作记入位掩码，其余非零强度的模拟动作另行记录，鼠标位置仅在变化时记录
+func (rec *Recording) encodeFrame(e *encoder, f input.Frame, prevX, prevY float32) {
+	var down, analog uint64
+	for i, action := range rec.Actions {
+		switch v := f.Values[action]; {
+		case v == 1:
+			down |= 1 << uint(i)
+		case v != 0:
+			analog |= 1 << uint(i)
+		}
+	}
+
+	flags := byte(0)
+	if analog != 0 {
+		flags |= flagAnalog
+	}
+	if f.MouseX != prevX || f.MouseY != prevY {
+		flags |= flagMouse
+	}
+	if f.ScrollX != 0 || f.ScrollY != 0 {
+		flags |= flagScroll
+	}
+
+	e.bytes([]byte{flags})
+	e.uvarint(down)
+	if flags&flagAnalog != 0 {
+		e.uvarint(analog)
+		for i, action := range rec.Actions {
+			if analog&(1<<uint(i)) != 0 {
+				e.float32(f.Values[action])
+			}
+		}
+	}
+	if flags&flagMouse != 0 {
+		e.float32(f.MouseX)
+		e.float32(f.MouseY)
+	}
+	if flags&flagScroll != 0 {
+		e.float32(f.ScrollX)
+		e.float32(f.ScrollY)
+	}
+}
+
+// decodeFrame, Recording 类读取单个输入快照的包内方法， encodeFrame 的逆操作
+func (rec *Recording) decodeFrame(d *decoder, prevX, prevY float32) input.Frame {
+	f := input.Frame{
+		Values: make(map[string]float32),
+		MouseX: prevX,
+		MouseY: prevY,
+	}
+	flags := d.bytes(1)
+	if d.err != nil {
+		return f
+	}
+	down := d.uvarint()
+	for i, action := range rec.Actions {
+		if down&(1<<uint(i)) != 0 {
+			f.Values[action] = 1
+		}
+	}
+	if flags[0]&flagAnalog != 0 {
+		analog := d.uvarint()
+		for i, action := range rec.Actions {
+			if analog&(1<<uint(i)) != 0 {
+				f.Values[action] = d.float32()
+			}
+		}
+	}
+	if flags[0]&flagMouse != 0 {
+		f.MouseX = d.float32()
+		f.MouseY = d.float32()
+	}
+	if flags[0]&flagScroll != 0 {
+		f.ScrollX = d.float32()
+		f.ScrollY = d.float32()
+	}
+	return f
+}
+
+// framesEqual, 判断两个输入快照是否相同的包内函数
+func framesEqual(a, b input.Frame) bool {
+	if a.MouseX != b.MouseX || a.MouseY != b.MouseY || a.ScrollX != b.ScrollX || a.ScrollY != b.ScrollY {
+		return false
+	}
+	if len(a.Values) != len(b.Values) {
+		return false
+	}
+	for k, v := range a.Values {
+		if bv, ok := b.Values[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// encoder, 录像写出辅助对象，记录首个写入错误
+type encoder struct {
+	w   io.Writer
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (e *encoder) bytes(b []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(b)
+	}
+}
+
+func (e *encoder) uvarint(v uint64) {
+	n := binary.PutUvarint(e.buf[:], v)
+	e.bytes(e.buf[:n])
+}
+
+func (e *encoder) uint64(v uint64) {
+	binary.LittleEndian.PutUint64(e.buf[:8], v)
+	e.bytes(e.buf[:8])
+}
+
+func (e *encoder) float64(v float64) {
+	e.uint64(math.Float64bits(v))
+}
+
+func (e *encoder) float32(v float32) {
+	binary.LittleEndian.PutUint32(e.buf[:4], math.Float32bits(v))
+	e.bytes(e.buf[:4])
+}
+
+// decoder, 录像读取辅助对象，记录首个读取错误
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *decoder) bytes(n int) []byte {
+	b := make([]byte, n)
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.r, b)
+	}
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(d.r)
+	d.err = err
+	return v
+}
+
+func (d *decoder) uint64() uint64 {
+	return binary.LittleEndian.Uint64(d.bytes(8))
+}
+
+func (d *decoder) float64() float64 {
+	return math.Float64frombits(d.uint64())
+}
+
+func (d *decoder) float32() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(d.bytes(4)))
+}
@@ -0,0 +1,117 @@
+package replay_test
+
+import (
+	"bytes"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/replay"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/core/scene"
+	"testing"
+)
+
+const step = 1.0 / 60
+
+// newScene, 构建无渲染环境的测试场景：地面、墙体与一个待销毁的移动物体
+func newScene() *scene.Scene {
+	sp := resolv.NewSpace()
+	floor := resolv.NewRectangle(0, 200, 640, 16, 0, 1, nil, nil)
+	floor.AddTags("solid")
+	wall := resolv.NewRectangle(300, 100, 16, 100, 0, 1, nil, nil)
+	wall.AddTags("solid")
+	debris := resolv.NewRectangle(100, 100, 8, 8, 0, 1, nil, nil)
+	debris.AddTags("isMove", "destroy")
+	sp.Add(floor, wall, debris)
+
+	p := scene.NewPlayer(32, 168, 16, 32, 0.5, 1, nil, nil)
+	p.SetMaxSpd(5)
+	return scene.NewScene(640, 240, p, sp, scene.NewDefaultCamera(0, 0, 320, 240), func() {})
+}
+
+// record, 在测试场景中按输入脚本录制，每个逻辑更新前按下脚本指定的按键
+func record(script func(i int) []input.Key, frames int) *replay.Recording {
+	s := newScene()
+	r := replay.NewRecorder(s.Input, step)
+	var held []input.Key
+	for i := 0; i < frames; i++ {
+		for _, k := range held {
+			s.Input.ReleaseKey(k)
+		}
+		held = script(i)
+		for _, k := range held {
+			s.Input.SetKeyDown(k)
+		}
+		s.Update(step)
+	}
+	return r.Finish(s.Input, s.Map)
+}
+
+func walkAndJump(i int) []input.Key {
+	switch {
+	case i < 60:
+		return []input.Key{input.KeyD}
+	case i < 70:
+		return []input.Key{input.KeyD, input.KeyW}
+	case i < 120:
+		return []input.Key{input.KeyA}
+	}
+	return nil
+}
+
+func TestRunMatchesRecording(t *testing.T) {
+	rec := record(walkAndJump, 180)
+	if len(rec.Frames) != 180 || !rec.HasChecksum {
+		t.Fatalf("recorded %d frames, checksum %v", len(rec.Frames), rec.HasChecksum)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := replay.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Checksum != rec.Checksum || len(loaded.Frames) != len(rec.Frames) {
+		t.Fatalf("round trip changed recording: checksum %x != %x, %d != %d frames",
+			loaded.Checksum, rec.Checksum, len(loaded.Frames), len(rec.Frames))
+	}
+
+	s := newScene()
+	if !replay.Run(loaded, s.Input, s, s.Map) {
+		t.Fatalf("replay checksum %x does not match recording %x", replay.Checksum(s.Map), rec.Checksum)
+	}
+}
+
+func TestRunDetectsDivergence(t *testing.T) {
+	rec := record(walkAndJump, 180)
+	other := record(func(i int) []input.Key { return nil }, 180)
+	if rec.Checksum == other.Checksum {
+		t.Fatal("different inputs produced the same checksum")
+	}
+
+	rec.Checksum = other.Checksum
+	s := newScene()
+	if replay.Run(rec, s.Input, s, s.Map) {
+		t.Fatal("replay matched a checksum recorded from different inputs")
+	}
+}
+
+func TestReadRejectsLongRun(t *testing.T) {
+	rec := &replay.Recording{Step: step, Frames: []input.Frame{{}}}
+	var buf bytes.Buffer
+	if err := rec.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// 将唯一一段的重复次数改写为超大值
+	data := buf.Bytes()
+	header := len("2DRP") + 1 + 8 + 1
+	if data[header] != 1 || data[header+1] != 1 {
+		t.Fatalf("unexpected encoding % x", data[header:])
+	}
+	corrupt := append([]byte{}, data[:header+1]...)
+	corrupt = append(corrupt, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f)
+	corrupt = append(corrupt, data[header+2:]...)
+	if _, err := replay.Read(bytes.NewReader(corrupt)); err == nil {
+		t.Fatal("expected an error for an oversized repeat count")
+	}
+}
@@ -33,7 +33,7 @@ func NewCircle(x, y, radius int32, friction, drawMulti float32, moveTextures, st
 // being wholly within the Circle.
 func (c *Circle) IsColliding(other Shape) bool {
 
-	switch b := other.(type) {
+	switch b := unwrap(other).(type) {
 
 	case *Circle:
 		return Distance(c.X, c.Y, b.X, b.Y) <= c.Radius+b.Radius
@@ -69,6 +69,11 @@ func (c *Circle) IsColliding(other Shape) bool {
 
 }
 
+// circle, 获取 Circle 自身的包内方法，供嵌入 Circle 的自定义类型参与碰撞检测
+func (c *Circle) circle() *Circle {
+	return c
+}
+
 // WouldBeColliding returns whether the Circle would be colliding with the specified other Shape if it were to move
 // in the specified direction.
 func (c *Circle) WouldBeColliding(other Shape, dx, dy int32) bool {
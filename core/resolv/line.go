@@ -44,7 +44,7 @@ func (l *Line) IsColliding(other Shape) bool {
 
 	colliding := len(intersectionPoints) > 0
 
-	r, ok := other.(*Rectangle)
+	r, ok := unwrap(other).(*Rectangle)
 	if ok && !colliding {
 		return (l.X >= r.X && l.Y >= r.Y && l.X < r.X+r.W && l.Y < r.Y+r.H) || (l.X2 >= r.X && l.Y2 >= r.Y && l.X2 < r.X+r.W && l.Y2 < r.Y+r.H)
 	}
@@ -53,6 +53,11 @@ func (l *Line) IsColliding(other Shape) bool {
 
 }
 
+// line, 获取 Line 自身的包内方法，供嵌入 Line 的自定义类型参与碰撞检测
+func (l *Line) line() *Line {
+	return l
+}
+
 // IntersectionPoint represents a point of intersection from a Line with another Shape.
 type IntersectionPoint struct {
 	X, Y  int32
@@ -66,7 +71,7 @@ func (l *Line) GetIntersectionPoints(other Shape) []IntersectionPoint {
 
 	var intersections []IntersectionPoint
 
-	switch b := other.(type) {
+	switch b := unwrap(other).(type) {
 
 	case *Line:
 
@@ -65,6 +65,9 @@ func NewMoveShape(x, y int32, rotate, friction, multiple float32, moveTextures [
 // 参数:
 //     delta: 上次更新后时延
 func (m *MoveShape) ToStand(delta float32) {
+	if len(m.standTextures) == 0 {
+		return
+	}
 	if m.standIndex >= len(m.standTextures) {
 		m.standIndex = 0
 	}
@@ -80,6 +83,9 @@ func (m *MoveShape) ToStand(delta float32) {
 // 参数:
 //     delta: 上次更新后时延
 func (m *MoveShape) ToMove(delta float32) {
+	if len(m.moveTextures) == 0 {
+		return
+	}
 	if m.moveIndex >= len(m.moveTextures) {
 		m.moveIndex = 0
 	}
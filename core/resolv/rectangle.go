@@ -31,7 +31,7 @@ func NewRectangle(x, y, w, h int32, friction, drawMulti float32, moveTextures, s
 // being wholly contained within the Rectangle.
 func (r *Rectangle) IsColliding(other Shape) bool {
 
-	switch b := other.(type) {
+	switch b := unwrap(other).(type) {
 	case *Rectangle:
 		return r.X > b.X-r.W && r.Y > b.Y-r.H && r.X < b.X+b.W && r.Y < b.Y+b.H
 	default:
@@ -40,6 +40,11 @@ func (r *Rectangle) IsColliding(other Shape) bool {
 
 }
 
+// rectangle, 获取 Rectangle 自身的包内方法，供嵌入 Rectangle 的自定义类型参与碰撞检测
+func (r *Rectangle) rectangle() *Rectangle {
+	return r
+}
+
 // WouldBeColliding returns whether the Rectangle would be colliding with the other Shape if it were to move in the
 // specified direction.
 func (r *Rectangle) WouldBeColliding(other Shape, dx, dy int32) bool {
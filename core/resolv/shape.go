@@ -9,6 +9,7 @@ import (
 	"github.com/ClessLi/2d-game-engin/core/render"
 	"github.com/ClessLi/2d-game-engin/resource"
 	"github.com/go-gl/mathgl/mgl32"
+	"math"
 )
 
 // Shape is a basic interface that describes a Shape that can be passed to collision testing and resolution functions and
@@ -48,6 +49,43 @@ type BasicShape struct {
 	IsXReverse bool
 	friction   float32
 	multiple   float32
+	// 上一次逻辑更新时的坐标，用于渲染插值
+	prevX, prevY int32
+	hasPrev      bool
+	// 渲染层序号
+	layer int
+}
+
+// unwrap, 获取形状对象所嵌入的基础形状对象的包内函数，嵌入 Rectangle、Circle 或 Line 的自定义类型（如角色、投射物）
+// 以其基础形状对象参与碰撞检测
+// 参数:
+//     shape: Shape 接口对象
+// 返回值:
+//     Shape 接口对象，未嵌入基础形状对象时返回 shape 本身
+func unwrap(shape Shape) Shape {
+	switch s := shape.(type) {
+	case *Rectangle, *Circle, *Line, *Space:
+		return shape
+	case interface{ rectangle() *Rectangle }:
+		return s.rectangle()
+	case interface{ circle() *Circle }:
+		return s.circle()
+	case interface{ line() *Line }:
+		return s.line()
+	}
+	return shape
+}
+
+// Interpolated, 可插值渲染的形状接口对象，用于固定步长循环下在前后两次逻辑状态之间渲染
+type Interpolated interface {
+	StorePrevXY()
+	LerpXY(alpha float32) (int32, int32)
+}
+
+// Layered, 可分层渲染的形状接口对象，镜头仅渲染其渲染层掩码包含的形状
+type Layered interface {
+	GetLayer() int
+	SetLayer(layer int)
 }
 
 // GetTags returns a reference to the the string array representing the tags on the BasicShape.
@@ -134,6 +172,41 @@ func (b *BasicShape) Move(x, y int32) {
 	b.Y += y
 }
 
+// StorePrevXY, BasicShape 类记录当前坐标为上一次逻辑状态的方法， Interpolated.StorePrevXY() 的实现
+func (b *BasicShape) StorePrevXY() {
+	b.prevX = b.X
+	b.prevY = b.Y
+	b.hasPrev = true
+}
+
+// LerpXY, BasicShape 类获取插值坐标的方法， Interpolated.LerpXY(alpha float32) (int32, int32) 的实现
+// 参数:
+//     alpha: 插值系数， 0 为上一次逻辑状态， 1 为当前逻辑状态
+// 返回值:
+//     int32, int32 类型，插值后的坐标；未记录上一次状态时返回当前坐标
+func (b *BasicShape) LerpXY(alpha float32) (int32, int32) {
+	if !b.hasPrev {
+		return b.X, b.Y
+	}
+	x := float32(b.prevX) + float32(b.X-b.prevX)*alpha
+	y := float32(b.prevY) + float32(b.Y-b.prevY)*alpha
+	return int32(math.Round(float64(x))), int32(math.Round(float64(y)))
+}
+
+// GetLayer, BasicShape 类获取渲染层序号的方法， Layered.GetLayer() int 的实现
+// 返回值:
+//     int 类型，默认为 0
+func (b *BasicShape) GetLayer() int {
+	return b.layer
+}
+
+// SetLayer, BasicShape 类设置渲染层序号的方法， Layered.SetLayer(layer int) 的实现
+// 参数:
+//     layer: 渲染层序号，取值 [0, render.MaxLayers)
+func (b *BasicShape) SetLayer(layer int) {
+	b.layer = layer
+}
+
 // ReverseX, BasicShape 类方向转换为水平向后的方法
 func (b *BasicShape) ReverseX() {
 	b.IsXReverse = true
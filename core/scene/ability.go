@@ -0,0 +1,371 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// AbilityBehaviour, 技能行为接口对象，定义技能激活、持续与结束时对玩家角色的处理
+type AbilityBehaviour interface {
+	// CanActivate, 判断技能当前能否激活
+	CanActivate(s *Scene, p *Player) bool
+	// Activate, 技能激活时调用
+	Activate(s *Scene, p *Player)
+	// Update, 技能持续期间每次更新时调用，返回 false 时技能提前结束
+	Update(s *Scene, p *Player, delta float64) bool
+	// End, 技能结束时调用
+	End(s *Scene, p *Player)
+}
+
+// Ability, 技能对象，由激活动作触发，记录冷却、持续时长与魔力消耗，同一时间玩家角色只有一个技能处于持续状态
+type Ability struct {
+	Name string
+	// 激活动作名称
+	Action string
+	// 为 true 时按住激活动作期间持续激活，松开时结束，且不打断其他持续中的技能；
+	// 否则仅在激活动作刚按下时激活，并打断其他持续中的技能
+	Hold bool
+	// 冷却时长，自技能激活时计时
+	CD float64
+	// 持续时长，不大于 0 时持续至技能行为结束
+	Duration float64
+	// 激活时消耗的魔力，取自玩家角色武器库的共享魔力
+	ManaCost float32
+	// 离地后可激活的次数，着地时恢复，为 0 时不限
+	AirLimit  int
+	Behaviour AbilityBehaviour
+	cdDelta   float64
+	elapsed   float64
+	airUsed   int
+	active    bool
+}
+
+// NewAbility, Ability 类实例初始化函数
+// 参数:
+//     name: 技能名称
+//     action: 激活动作名称
+//     cd: 冷却时长
+//     duration: 持续时长，不大于 0 时持续至技能行为结束
+//     behaviour: AbilityBehaviour 接口对象
+// 返回值:
+//     Ability 类指针
+func NewAbility(name, action string, cd, duration float64, behaviour AbilityBehaviour) *Ability {
+	return &Ability{Name: name, Action: action, CD: cd, Duration: duration, Behaviour: behaviour}
+}
+
+// Active, Ability 类判断技能是否处于持续状态的方法
+// 返回值:
+//     bool 类型
+func (a *Ability) Active() bool {
+	return a.active
+}
+
+// CoolDownState, Ability 类获取冷却状态的方法，用于 HUD 显示
+// 返回值:
+//     float64 类型，剩余冷却时长，不小于 0
+//     float64 类型，冷却总时长
+func (a *Ability) CoolDownState() (float64, float64) {
+	return coolDownLeft(a.cdDelta), a.CD
+}
+
+// AddAbility, Player 类添加技能的方法
+// 参数:
+//     abilities: Ability 类指针列表
+func (p *Player) AddAbility(abilities ...*Ability) {
+	p.Abilities = append(p.Abilities, abilities...)
+}
+
+// Ability, Player 类按名称获取技能的方法
+// 参数:
+//     name: 技能名称
+// 返回值:
+//     Ability 类指针，不存在时为 nil
+func (p *Player) Ability(name string) *Ability {
+	for _, a := range p.Abilities {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// ActiveAbility, Player 类获取持续中技能的方法
+// 返回值:
+//     Ability 类指针，无持续中技能时为 nil
+func (p *Player) ActiveAbility() *Ability {
+	return p.ability
+}
+
+// updateAbilities, Scene 类冷却、推进并按输入激活玩家角色技能的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateAbilities(delta float64) {
+	p := s.Player
+	for _, a := range p.Abilities {
+		if a.cdDelta > 0 {
+			a.cdDelta -= delta
+		}
+		if p.onGround {
+			a.airUsed = 0
+		}
+	}
+
+	if a := p.ability; a != nil {
+		a.elapsed += delta
+		if p.HasTags("isDead") ||
+			(a.Duration > 0 && a.elapsed >= a.Duration) ||
+			(a.Hold && !s.Input.Pressed(a.Action)) ||
+			!a.Behaviour.Update(s, p, delta) {
+			s.endAbility()
+		}
+	}
+	if p.HasTags("isDead") {
+		return
+	}
+
+	for _, a := range p.Abilities {
+		if a.active || a.cdDelta > 0 || a.Behaviour == nil {
+			continue
+		}
+		if a.AirLimit > 0 && !p.onGround && a.airUsed >= a.AirLimit {
+			continue
+		}
+		if a.Hold {
+			if p.ability != nil || !s.Input.Pressed(a.Action) {
+				continue
+			}
+		} else if !s.Input.JustPressed(a.Action) {
+			continue
+		}
+		if !a.Behaviour.CanActivate(s, p) || !p.spendMana(a.ManaCost) {
+			continue
+		}
+		s.endAbility()
+		a.active = true
+		a.elapsed = 0
+		a.cdDelta = a.CD
+		if !p.onGround {
+			a.airUsed++
+		}
+		p.ability = a
+		a.Behaviour.Activate(s, p)
+		s.emit(&AbilityActivated{Ability: a})
+		break
+	}
+}
+
+// endAbility, Scene 类结束玩家角色持续中技能的包内方法
+func (s *Scene) endAbility() {
+	p := s.Player
+	a := p.ability
+	if a == nil {
+		return
+	}
+	a.active = false
+	p.ability = nil
+	a.Behaviour.End(s, p)
+}
+
+// spendMana, Player 类扣除技能魔力消耗的包内方法
+// 参数:
+//     cost: 魔力消耗
+// 返回值:
+//     bool 类型，魔力不足时不扣除并返回 false
+func (p *Player) spendMana(cost float32) bool {
+	if cost <= 0 {
+		return true
+	}
+	if p.Inventory == nil || p.Inventory.Mana == nil {
+		return false
+	}
+	return p.Inventory.Mana.Spend(cost)
+}
+
+// Dash, 冲刺技能行为，沿攻击矢量所指的八个方向之一高速移动，冲刺期间无敌，撞墙时提前结束
+type Dash struct {
+	// 冲刺速度
+	Speed float32
+	// 冲刺期间的无敌时长
+	IFrames float64
+	// 冲刺结束时保留的速度比例
+	EndSpeed float32
+	dir      mgl32.Vec2
+}
+
+// NewDash, Dash 类实例初始化函数
+// 返回值:
+//     Dash 类指针
+func NewDash() *Dash {
+	return &Dash{Speed: 18, IFrames: 0.2, EndSpeed: 0.3}
+}
+
+// CanActivate, Dash 类 AbilityBehaviour.CanActivate(s *Scene, p *Player) bool 的实现
+func (d *Dash) CanActivate(s *Scene, p *Player) bool {
+	return true
+}
+
+// Activate, Dash 类 AbilityBehaviour.Activate(s *Scene, p *Player) 的实现
+func (d *Dash) Activate(s *Scene, p *Player) {
+	d.dir = s.dashDirection()
+	if d.dir[0] != 0 {
+		p.IsXReverse = d.dir[0] < 0
+	}
+	if p.Health != nil {
+		p.Health.SetInvincible(d.IFrames)
+	}
+	p.IsMove = true
+	p.SetSpd(d.dir[0]*d.Speed, d.dir[1]*d.Speed)
+}
+
+// Update, Dash 类 AbilityBehaviour.Update(s *Scene, p *Player, delta float64) bool 的实现
+func (d *Dash) Update(s *Scene, p *Player, delta float64) bool {
+	if s.playerBlocked(sign(d.dir[0]), 0) || s.playerBlocked(0, sign(d.dir[1])) {
+		return false
+	}
+	p.IsMove = true
+	p.SetSpd(d.dir[0]*d.Speed, d.dir[1]*d.Speed)
+	return true
+}
+
+// End, Dash 类 AbilityBehaviour.End(s *Scene, p *Player) 的实现
+func (d *Dash) End(s *Scene, p *Player) {
+	p.SpeedX *= d.EndSpeed
+	p.SpeedY *= d.EndSpeed
+}
+
+// dashDirection, Scene 类获取玩家角色冲刺方向的包内方法，键盘瞄准上下且无水平移动输入时垂直冲刺，
+// 鼠标瞄准时取最接近的八方向
+// 返回值:
+//     mgl32.Vec2 类，单位矢量
+func (s *Scene) dashDirection() mgl32.Vec2 {
+	p := s.Player
+	vec := p.AtkVec
+	if !s.Input.Pressed(input.ActionMouseAim) && vec[1] != 0 &&
+		!s.Input.Pressed(input.ActionMoveLeft) && !s.Input.Pressed(input.ActionMoveRight) {
+		vec[0] = 0
+	}
+	if vec.Len() == 0 {
+		vec = mgl32.Vec2{1, 0}
+		if p.IsXReverse {
+			vec[0] = -1
+		}
+	}
+	step := math.Pi / 4
+	angle := math.Round(math.Atan2(float64(vec[1]), float64(vec[0]))/step) * step
+	return mgl32.Vec2{float32(math.Cos(angle)), float32(math.Sin(angle))}
+}
+
+// GroundPound, 下砸技能行为，空中激活后垂直高速下落，落地时对周围实体造成伤害并震动镜头
+type GroundPound struct {
+	// 下落速度
+	Speed float32
+	// 落地冲击范围尺寸，以角色底部中央为中心
+	W, H int32
+	// 冲击伤害数值与击退力度
+	Damage    int
+	Knockback float32
+	// 落地时的镜头震动强度增量，为 0 时不震动
+	Trauma float32
+}
+
+// NewGroundPound, GroundPound 类实例初始化函数
+// 返回值:
+//     GroundPound 类指针
+func NewGroundPound() *GroundPound {
+	return &GroundPound{Speed: 20, W: 200, H: 60, Damage: 2, Knockback: 12, Trauma: 0.4}
+}
+
+// CanActivate, GroundPound 类 AbilityBehaviour.CanActivate(s *Scene, p *Player) bool 的实现
+func (g *GroundPound) CanActivate(s *Scene, p *Player) bool {
+	return !p.onGround && p.region.mode == MoveNormal
+}
+
+// Activate, GroundPound 类 AbilityBehaviour.Activate(s *Scene, p *Player) 的实现
+func (g *GroundPound) Activate(s *Scene, p *Player) {
+	p.SetSpd(0, g.Speed)
+}
+
+// Update, GroundPound 类 AbilityBehaviour.Update(s *Scene, p *Player, delta float64) bool 的实现，落地时结束
+func (g *GroundPound) Update(s *Scene, p *Player, delta float64) bool {
+	if p.onGround || s.playerBlocked(0, 1) {
+		return false
+	}
+	p.SetSpd(0, g.Speed)
+	return true
+}
+
+// End, GroundPound 类 AbilityBehaviour.End(s *Scene, p *Player) 的实现，落地时生成冲击判定框
+func (g *GroundPound) End(s *Scene, p *Player) {
+	if p.HasTags("isDead") || !(p.onGround || s.playerBlocked(0, 1)) {
+		return
+	}
+	x, y := p.GetXY()
+	box := NewHitbox(x+p.W/2-g.W/2, y+p.H-g.H/2, g.W, g.H, 0.1, nil)
+	box.SetData(&Hazard{Damage: g.Damage, Knockback: g.Knockback})
+	result := &AttackResult{Shapes: []resolv.Shape{box}}
+	if g.Trauma > 0 {
+		result.Effects = append(result.Effects, ShakeEffect(g.Trauma))
+	}
+	s.applyAttack(p, result)
+}
+
+// Glide, 滑翔技能行为，下落时限制下落速度，落地时结束，宜以按住激活（Ability.Hold）方式使用
+type Glide struct {
+	// 最大下落速度
+	FallSpeed float32
+}
+
+// NewGlide, Glide 类实例初始化函数
+// 返回值:
+//     Glide 类指针
+func NewGlide() *Glide {
+	return &Glide{FallSpeed: 2}
+}
+
+// CanActivate, Glide 类 AbilityBehaviour.CanActivate(s *Scene, p *Player) bool 的实现，仅在空中下落时可激活
+func (g *Glide) CanActivate(s *Scene, p *Player) bool {
+	return !p.onGround && p.SpeedY > 0 && p.region.mode == MoveNormal
+}
+
+// Activate, Glide 类 AbilityBehaviour.Activate(s *Scene, p *Player) 的实现
+func (g *Glide) Activate(s *Scene, p *Player) {
+	g.limit(p)
+}
+
+// Update, Glide 类 AbilityBehaviour.Update(s *Scene, p *Player, delta float64) bool 的实现，落地时结束
+func (g *Glide) Update(s *Scene, p *Player, delta float64) bool {
+	if p.onGround {
+		return false
+	}
+	g.limit(p)
+	return true
+}
+
+// End, Glide 类 AbilityBehaviour.End(s *Scene, p *Player) 的实现
+func (g *Glide) End(s *Scene, p *Player) {}
+
+// limit, Glide 类限制下落速度的包内方法
+// 参数:
+//     p: Player 类指针
+func (g *Glide) limit(p *Player) {
+	if p.SpeedY > g.FallSpeed {
+		p.SpeedY = g.FallSpeed
+	}
+}
+
+// sign, 获取数值符号的包内函数
+// 参数:
+//     v: 数值
+// 返回值:
+//     int32 类型，-1、0 或 1
+func sign(v float32) int32 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
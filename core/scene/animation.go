@@ -0,0 +1,107 @@
+package scene
+
+import "github.com/ClessLi/2d-game-engin/resource"
+
+// AnimationClip, 动画片段对象，由若干纹理帧组成
+type AnimationClip struct {
+	Frames []*resource.Texture2D
+	// 每帧持续时长，单位为秒
+	FrameTime float32
+	// 是否循环播放，不循环时停留在最后一帧
+	Loop bool
+}
+
+// NewAnimationClip, AnimationClip 类实例初始化函数
+// 参数:
+//     frameTime: 每帧持续时长
+//     loop: 是否循环播放
+//     names: 纹理帧的 Texture 对象名列表
+// 返回值:
+//     AnimationClip 类指针
+func NewAnimationClip(frameTime float32, loop bool, names ...string) *AnimationClip {
+	return &AnimationClip{
+		Frames:    resource.GetTexturesByName(names...),
+		FrameTime: frameTime,
+		Loop:      loop,
+	}
+}
+
+// Duration, AnimationClip 类获取播放一遍所需时长的方法
+// 返回值:
+//     float32 类型，单位为秒
+func (a *AnimationClip) Duration() float32 {
+	return a.FrameTime * float32(len(a.Frames))
+}
+
+// Animator, 动画播放对象，记录当前动画片段与播放进度
+type Animator struct {
+	clip    *AnimationClip
+	index   int
+	elapsed float32
+}
+
+// Play, Animator 类播放动画片段的方法，片段与当前片段相同时继续播放
+// 参数:
+//     clip: AnimationClip 类指针，为 nil 时停止播放并保留当前纹理
+func (a *Animator) Play(clip *AnimationClip) {
+	if clip == a.clip {
+		return
+	}
+	a.clip = clip
+	a.index = 0
+	a.elapsed = 0
+}
+
+// Restart, Animator 类从第一帧重新播放当前动画片段的方法
+func (a *Animator) Restart() {
+	a.index = 0
+	a.elapsed = 0
+}
+
+// Clip, Animator 类获取当前动画片段的方法
+// 返回值:
+//     AnimationClip 类指针
+func (a *Animator) Clip() *AnimationClip {
+	return a.clip
+}
+
+// Update, Animator 类推进播放进度的方法
+// 参数:
+//     delta: 与上次更新的时延
+func (a *Animator) Update(delta float32) {
+	if a.clip == nil || len(a.clip.Frames) == 0 {
+		return
+	}
+	a.elapsed += delta
+	for a.clip.FrameTime > 0 && a.elapsed >= a.clip.FrameTime {
+		a.elapsed -= a.clip.FrameTime
+		if a.index < len(a.clip.Frames)-1 {
+			a.index++
+		} else if a.clip.Loop {
+			a.index = 0
+		} else {
+			a.elapsed = 0
+			break
+		}
+	}
+}
+
+// Texture, Animator 类获取当前帧纹理的方法
+// 返回值:
+//     resource.Texture2D 类指针，无动画片段时为 nil
+func (a *Animator) Texture() *resource.Texture2D {
+	if a.clip == nil || len(a.clip.Frames) == 0 {
+		return nil
+	}
+	return a.clip.Frames[a.index]
+}
+
+// Finished, Animator 类判断非循环动画片段是否已播放至最后一帧的方法
+// 返回值:
+//     bool 类型，循环片段始终为 false
+func (a *Animator) Finished() bool {
+	if a.clip == nil {
+		return true
+	}
+	return !a.clip.Loop && a.index >= len(a.clip.Frames)-1
+}
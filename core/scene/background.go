@@ -0,0 +1,124 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// BackgroundLayer, 视差背景层对象，先于场景形状对象、按加入顺序渲染于其下方的平铺或单张纹理，
+// 随镜头移动的距离由滚动系数决定，以形成远近不同的视差效果
+type BackgroundLayer struct {
+	Texture *resource.Texture2D
+	// 背景层原点场景坐标
+	X, Y float32
+	// 单张纹理渲染尺寸，为 0 时取纹理图片尺寸
+	W, H float32
+	// 滚动系数，相对镜头移动的比例， 0 为固定于镜头画面， 1 为与场景形状对象同步移动
+	ScrollX, ScrollY float32
+	// 自动滚动速度，单位为像素/秒
+	AutoScrollX, AutoScrollY float32
+	// 是否在水平、垂直方向重复平铺
+	RepeatX, RepeatY bool
+	Color            mgl32.Vec3
+	Hidden           bool
+
+	// 自动滚动累计偏移
+	offsetX, offsetY float32
+}
+
+// NewBackgroundLayer, BackgroundLayer 类实例初始化函数，默认水平方向重复平铺
+// 参数:
+//     texture: 背景纹理
+//     scrollX, scrollY: 滚动系数
+// 返回值:
+//     BackgroundLayer 类指针
+func NewBackgroundLayer(texture *resource.Texture2D, scrollX, scrollY float32) *BackgroundLayer {
+	return &BackgroundLayer{
+		Texture: texture,
+		ScrollX: scrollX,
+		ScrollY: scrollY,
+		RepeatX: true,
+		Color:   mgl32.Vec3{1, 1, 1},
+	}
+}
+
+// Update, BackgroundLayer 类推进自动滚动的方法
+// 参数:
+//     delta: 与上次更新的时延
+func (b *BackgroundLayer) Update(delta float64) {
+	w, h := b.tileSize()
+	b.offsetX = wrapOffset(b.offsetX+b.AutoScrollX*float32(delta), w, b.RepeatX)
+	b.offsetY = wrapOffset(b.offsetY+b.AutoScrollY*float32(delta), h, b.RepeatY)
+}
+
+// Draw, BackgroundLayer 类以指定镜头渲染背景层的方法，重复平铺的方向以单个精灵覆盖镜头可视范围，
+// 通过纹理坐标偏移与缩放实现平铺与滚动
+// 参数:
+//     renderer: render.SpriteRenderer 类指针，指定渲染器
+//     c: Camera 类指针
+func (b *BackgroundLayer) Draw(renderer *render.SpriteRenderer, c *Camera) {
+	if b.Hidden || b.Texture == nil {
+		return
+	}
+	tileW, tileH := b.tileSize()
+	if tileW <= 0 || tileH <= 0 {
+		return
+	}
+
+	// 背景层原点随镜头移动 (1 - 滚动系数) 倍的距离
+	originX := b.X + b.offsetX + c.X*(1-b.ScrollX)
+	originY := b.Y + b.offsetY + c.Y*(1-b.ScrollY)
+	viewX, viewY, viewW, viewH := c.Bounds()
+
+	x, w, u, uScale := tileSpan(originX, tileW, viewX, viewW, b.RepeatX)
+	y, h, v, vScale := tileSpan(originY, tileH, viewY, viewH, b.RepeatY)
+	renderer.DrawSpriteUV(b.Texture, &mgl32.Vec2{x, y}, &mgl32.Vec2{w, h}, 0, &b.Color, false, mgl32.Vec4{u, v, uScale, vScale})
+}
+
+// tileSize, BackgroundLayer 类获取单张纹理渲染尺寸的包内方法
+// 返回值:
+//     float32, float32 类型
+func (b *BackgroundLayer) tileSize() (float32, float32) {
+	w, h := b.W, b.H
+	if b.Texture != nil {
+		if w <= 0 {
+			w = float32(b.Texture.W)
+		}
+		if h <= 0 {
+			h = float32(b.Texture.H)
+		}
+	}
+	return w, h
+}
+
+// tileSpan, 计算背景层单一方向渲染范围与纹理坐标的包内函数
+// 参数:
+//     origin: 背景层原点坐标
+//     tile: 单张纹理渲染尺寸
+//     view, viewSize: 镜头可视范围起点与尺寸
+//     repeat: 是否重复平铺
+// 返回值:
+//     pos, size: 渲染位置与尺寸
+//     uv, uvScale: 纹理坐标偏移与缩放
+func tileSpan(origin, tile, view, viewSize float32, repeat bool) (pos, size, uv, uvScale float32) {
+	if !repeat {
+		return origin, tile, 0, 1
+	}
+	return view, viewSize, (view - origin) / tile, viewSize / tile
+}
+
+// wrapOffset, 将自动滚动偏移限制在单张纹理尺寸内的包内函数，避免长时间滚动后浮点精度下降
+// 参数:
+//     offset: 累计偏移
+//     tile: 单张纹理渲染尺寸
+//     repeat: 是否重复平铺，不重复时不做限制
+// 返回值:
+//     float32 类型
+func wrapOffset(offset, tile float32, repeat bool) float32 {
+	if !repeat || tile <= 0 {
+		return offset
+	}
+	return float32(math.Mod(float64(offset), float64(tile)))
+}
@@ -0,0 +1,217 @@
+package scene
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Patrol, 巡逻行为，敌人依次往返于若干巡逻点之间；地面敌人仅在水平方向移动
+type Patrol struct {
+	Points []mgl32.Vec2
+	Speed  float32
+	index  int
+}
+
+// NewPatrol, Patrol 类实例初始化函数
+// 参数:
+//     speed: 移动速度
+//     points: 巡逻点场景坐标列表，敌人中心依次移动至各巡逻点
+// 返回值:
+//     Patrol 类指针
+func NewPatrol(speed float32, points ...mgl32.Vec2) *Patrol {
+	return &Patrol{Points: points, Speed: speed}
+}
+
+// Update, Patrol 类 Behaviour.Update(s *Scene, e *Enemy, delta float64) 的实现
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (p *Patrol) Update(s *Scene, e *Enemy, delta float64) {
+	if len(p.Points) == 0 {
+		e.SpeedX = 0
+		return
+	}
+	if p.index >= len(p.Points) {
+		p.index = 0
+	}
+	target := p.Points[p.index]
+	cx, cy := e.Center()
+	dir := mgl32.Vec2{target[0] - float32(cx), target[1] - float32(cy)}
+	if !e.Flying {
+		dir[1] = 0
+	}
+	// 到达巡逻点后前往下一个巡逻点
+	if dir.Len() <= p.Speed {
+		p.index = (p.index + 1) % len(p.Points)
+		e.SpeedX = 0
+		if e.Flying {
+			e.SpeedY = 0
+		}
+		return
+	}
+	moveToward(e, dir, p.Speed)
+}
+
+// FlyToward, 飞行追踪行为，飞行敌人朝玩家角色飞行，并叠加正弦上下浮动
+type FlyToward struct {
+	Speed float32
+	// 浮动幅度（速度）与频率（弧度/秒）
+	BobAmplitude, BobFrequency float32
+	time                       float64
+}
+
+// NewFlyToward, FlyToward 类实例初始化函数
+// 参数:
+//     speed: 飞行速度
+//     amplitude: 浮动幅度
+//     frequency: 浮动频率
+// 返回值:
+//     FlyToward 类指针
+func NewFlyToward(speed, amplitude, frequency float32) *FlyToward {
+	return &FlyToward{Speed: speed, BobAmplitude: amplitude, BobFrequency: frequency}
+}
+
+// Update, FlyToward 类 Behaviour.Update(s *Scene, e *Enemy, delta float64) 的实现
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (f *FlyToward) Update(s *Scene, e *Enemy, delta float64) {
+	f.time += delta
+	dir, _ := toPlayer(s, e)
+	if s.Player.HasTags("isDead") {
+		dir = mgl32.Vec2{}
+	}
+	moveToward(e, dir, f.Speed)
+	e.SpeedY += f.BobAmplitude * float32(math.Sin(f.time*float64(f.BobFrequency)))
+}
+
+// Chase, 追击行为，玩家角色进入追击范围时朝其移动，否则执行空闲行为
+type Chase struct {
+	Range float32
+	Speed float32
+	// 玩家角色不在追击范围内时执行的行为，为 nil 时原地不动
+	Idle Behaviour
+	// 攻击距离，玩家角色处于该距离内时敌人朝其攻击，为 0 时不攻击
+	AttackRange float32
+}
+
+// NewChase, Chase 类实例初始化函数
+// 参数:
+//     rng: 追击范围
+//     speed: 追击速度
+//     idle: 空闲行为
+// 返回值:
+//     Chase 类指针
+func NewChase(rng, speed float32, idle Behaviour) *Chase {
+	return &Chase{Range: rng, Speed: speed, Idle: idle}
+}
+
+// Update, Chase 类 Behaviour.Update(s *Scene, e *Enemy, delta float64) 的实现
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (c *Chase) Update(s *Scene, e *Enemy, delta float64) {
+	dir, dist := toPlayer(s, e)
+	if dist <= c.Range && !s.Player.HasTags("isDead") {
+		if !e.Flying {
+			dir[1] = 0
+		}
+		moveToward(e, dir, c.Speed)
+		if dist <= c.AttackRange && dir.Len() > 0 {
+			e.AtkVec = dir.Normalize()
+			s.applyAttack(e, e.Attack())
+		}
+		return
+	}
+	if c.Idle != nil {
+		c.Idle.Update(s, e, delta)
+		return
+	}
+	moveToward(e, mgl32.Vec2{}, 0)
+}
+
+// KeepDistance, 远程行为，与玩家角色保持一定距离，并在射程内朝其攻击
+type KeepDistance struct {
+	// 期望保持的距离及允许的偏差
+	Distance, Tolerance float32
+	// 射程
+	Range float32
+	Speed float32
+}
+
+// NewKeepDistance, KeepDistance 类实例初始化函数
+// 参数:
+//     distance: 期望保持的距离
+//     rng: 射程
+//     speed: 移动速度
+// 返回值:
+//     KeepDistance 类指针
+func NewKeepDistance(distance, rng, speed float32) *KeepDistance {
+	return &KeepDistance{Distance: distance, Tolerance: 32, Range: rng, Speed: speed}
+}
+
+// Update, KeepDistance 类 Behaviour.Update(s *Scene, e *Enemy, delta float64) 的实现
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (k *KeepDistance) Update(s *Scene, e *Enemy, delta float64) {
+	dir, dist := toPlayer(s, e)
+	if s.Player.HasTags("isDead") || dist > k.Range {
+		moveToward(e, mgl32.Vec2{}, 0)
+		return
+	}
+
+	move := dir
+	if !e.Flying {
+		move[1] = 0
+	}
+	switch {
+	case dist < k.Distance-k.Tolerance:
+		moveToward(e, move.Mul(-1), k.Speed)
+	case dist > k.Distance+k.Tolerance:
+		moveToward(e, move, k.Speed)
+	default:
+		moveToward(e, mgl32.Vec2{}, 0)
+	}
+
+	// 朝向并瞄准玩家角色
+	if dir.Len() > 0 {
+		e.AtkVec = dir.Normalize()
+		e.IsXReverse = dir[0] < 0
+	}
+	s.applyAttack(e, e.Attack())
+}
+
+// toPlayer, 获取敌人中心指向玩家角色中心矢量的包内函数
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+// 返回值:
+//     mgl32.Vec2 类，指向玩家角色的矢量
+//     float32 类型，与玩家角色的距离
+func toPlayer(s *Scene, e *Enemy) (mgl32.Vec2, float32) {
+	px, py := s.Player.Center()
+	ex, ey := e.Center()
+	dir := mgl32.Vec2{float32(px - ex), float32(py - ey)}
+	return dir, dir.Len()
+}
+
+// moveToward, 设置敌人朝指定方向移动速度的包内函数，地面敌人仅设置水平速度
+// 参数:
+//     e: Enemy 类指针
+//     dir: 移动方向，零矢量表示停止
+//     speed: 移动速度
+func moveToward(e *Enemy, dir mgl32.Vec2, speed float32) {
+	var v mgl32.Vec2
+	if dir.Len() > 0 {
+		v = dir.Normalize().Mul(speed)
+	}
+	e.SpeedX = v[0]
+	if e.Flying {
+		e.SpeedY = v[1]
+	}
+}
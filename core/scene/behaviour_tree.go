@@ -0,0 +1,104 @@
+package scene
+
+import "github.com/ClessLi/2d-game-engin/core/bt"
+
+// 行为树黑板中由 TreeBehaviour 写入的键
+const (
+	// BlackboardScene, 当前场景，值为 Scene 类指针
+	BlackboardScene = "scene"
+	// BlackboardEnemy, 行为树所属敌人，值为 Enemy 类指针
+	BlackboardEnemy = "enemy"
+)
+
+// TreeBehaviour, 行为树行为，以行为树驱动敌人，适用于首领等决策复杂的敌人
+type TreeBehaviour struct {
+	Tree *bt.Tree
+}
+
+// NewTreeBehaviour, TreeBehaviour 类实例初始化函数
+// 参数:
+//     tree: bt.Tree 类指针，每个敌人应持有独立的行为树
+// 返回值:
+//     TreeBehaviour 类指针
+func NewTreeBehaviour(tree *bt.Tree) *TreeBehaviour {
+	return &TreeBehaviour{Tree: tree}
+}
+
+// Update, TreeBehaviour 类 Behaviour.Update(s *Scene, e *Enemy, delta float64) 的实现，执行前将场景与敌人写入黑板
+// 参数:
+//     s: Scene 类指针
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (t *TreeBehaviour) Update(s *Scene, e *Enemy, delta float64) {
+	if t.Tree == nil {
+		return
+	}
+	t.Tree.Blackboard.Set(BlackboardScene, s)
+	t.Tree.Blackboard.Set(BlackboardEnemy, e)
+	t.Tree.Tick(delta)
+}
+
+// BehaviourAction, 将 Behaviour 包装为行为树动作函数的函数，动作始终处于执行中，
+// 用于在行为树中复用巡逻、追击等既有行为
+// 参数:
+//     b: Behaviour 接口对象
+// 返回值:
+//     bt.ActionFunc 类
+func BehaviourAction(b Behaviour) bt.ActionFunc {
+	return func(ctx *bt.Context) bt.Status {
+		s, e := treeActors(ctx)
+		if s == nil || e == nil {
+			return bt.Failure
+		}
+		b.Update(s, e, ctx.Delta)
+		return bt.Running
+	}
+}
+
+// PlayerWithin, 生成判断玩家角色是否存活且处于指定范围内的行为树条件函数的函数
+// 参数:
+//     rng: 范围
+// 返回值:
+//     bt.ConditionFunc 类
+func PlayerWithin(rng float32) bt.ConditionFunc {
+	return func(ctx *bt.Context) bool {
+		s, e := treeActors(ctx)
+		if s == nil || e == nil || s.Player.HasTags("isDead") {
+			return false
+		}
+		_, dist := toPlayer(s, e)
+		return dist <= rng
+	}
+}
+
+// EnemyAttack, 敌人朝玩家角色攻击的行为树动作函数，攻击生效时成功，无武器或冷却中时失败
+// 参数:
+//     ctx: bt.Context 类指针
+// 返回值:
+//     bt.Status 类
+func EnemyAttack(ctx *bt.Context) bt.Status {
+	s, e := treeActors(ctx)
+	if s == nil || e == nil {
+		return bt.Failure
+	}
+	if dir, _ := toPlayer(s, e); dir.Len() > 0 {
+		e.AtkVec = dir.Normalize()
+		e.IsXReverse = dir[0] < 0
+	}
+	if !s.applyAttack(e, e.Attack()) {
+		return bt.Failure
+	}
+	return bt.Success
+}
+
+// treeActors, 从行为树黑板获取场景与敌人的包内函数
+// 参数:
+//     ctx: bt.Context 类指针
+// 返回值:
+//     Scene 类指针，不存在时为 nil
+//     Enemy 类指针，不存在时为 nil
+func treeActors(ctx *bt.Context) (*Scene, *Enemy) {
+	s, _ := ctx.Blackboard.Get(BlackboardScene).(*Scene)
+	e, _ := ctx.Blackboard.Get(BlackboardEnemy).(*Enemy)
+	return s, e
+}
@@ -1,13 +1,55 @@
 package scene
 
-import "github.com/go-gl/mathgl/mgl32"
+import (
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Viewport, 视口对象，定义镜头画面在窗口中的显示区域，坐标为以窗口左上角为原点的像素坐标
+type Viewport struct {
+	X, Y, W, H float32
+}
+
+// IsZero, Viewport 类判断是否未设置的方法
+// 返回值:
+//     bool 类型，未设置的视口表示整个窗口
+func (v Viewport) IsZero() bool {
+	return v.W <= 0 || v.H <= 0
+}
+
+// Contains, Viewport 类判断窗口坐标是否在视口内的方法
+// 参数:
+//     x, y: 窗口像素坐标
+// 返回值:
+//     bool 类型
+func (v Viewport) Contains(x, y float32) bool {
+	return x >= v.X && x < v.X+v.W && y >= v.Y && y < v.Y+v.H
+}
 
 // Camera, 镜头对象，定义了镜头（屏幕）画面显示对象的基础信息
 type Camera struct {
-	X, Y        float32 // 坐标
-	W, H        float32 // 场景尺寸、屏幕尺寸
-	front, up   mgl32.Vec3
-	movementSpd float32 // 镜头转移速度
+	X, Y      float32 // 坐标
+	W, H      float32 // 场景尺寸、屏幕尺寸
+	front, up mgl32.Vec3
+	// 缩放倍数，大于 1 为放大，以镜头画面中心为缩放中心
+	Zoom float32
+	// 旋转弧度，以镜头画面中心为旋转中心
+	Rotation float32
+	// 镜头震动配置与状态
+	Shake *CameraShake
+	// 视口，为零值时镜头画面占满整个窗口
+	Viewport Viewport
+	// 跟随目标，为 nil 时跟随场景玩家角色
+	Target resolv.Shape
+	// 渲染层掩码，镜头仅渲染该掩码包含的渲染层
+	LayerMask render.LayerMask
+	// 镜头跟随配置，为 nil 时镜头始终以跟随目标为中心
+	FollowConfig *CameraFollow
+	follow       followState
+	// 上一次逻辑更新时的坐标，用于渲染插值
+	prevX, prevY float32
 }
 
 // NewDefaultCamera, Camera 类默认实例初始化的函数
@@ -18,13 +60,17 @@ type Camera struct {
 //     Camera 类指针
 func NewDefaultCamera(X, Y, W, H float32) *Camera {
 	return &Camera{
-		X:           X,
-		Y:           Y,
-		W:           W,
-		H:           H,
-		front:       mgl32.Vec3{0, 0, -1},
-		up:          mgl32.Vec3{0, 1, 0},
-		movementSpd: float32(100),
+		X:         X,
+		Y:         Y,
+		W:         W,
+		H:         H,
+		front:     mgl32.Vec3{0, 0, -1},
+		up:        mgl32.Vec3{0, 1, 0},
+		Zoom:      1,
+		Shake:     NewDefaultCameraShake(),
+		LayerMask: render.AllLayers,
+		prevX:     X,
+		prevY:     Y,
 	}
 }
 
@@ -39,9 +85,142 @@ func (c *Camera) GetPosition() mgl32.Vec3 {
 // 返回值:
 //     float32 类型指针
 func (c *Camera) GetViewMatrix() *float32 {
+	view := c.viewMatrix()
+	return &view[0]
+}
+
+// GetProjection, Camera 类获取正交投影矩阵的方法
+// 返回值:
+//     mgl32.Mat4 类，以屏幕左上角为原点的正交投影
+func (c *Camera) GetProjection() mgl32.Mat4 {
+	// mgl32.Ortho(0, --投影宽度, --投影高度, 0, -1, 1)
+	return mgl32.Ortho(0, c.W, c.H, 0, -1, 1)
+}
+
+// ScreenToWorld, Camera 类将屏幕坐标转换为场景坐标的方法
+// 参数:
+//     x, y: 屏幕像素坐标，原点为镜头画面左上角
+// 返回值:
+//     float32, float32 类型，场景坐标
+func (c *Camera) ScreenToWorld(x, y float32) (float32, float32) {
+	ndc := mgl32.Vec4{2*x/c.W - 1, 1 - 2*y/c.H, 0, 1}
+	inv := c.GetProjection().Mul4(c.viewMatrix()).Inv()
+	world := inv.Mul4x1(ndc)
+	return world[0] / world[3], world[1] / world[3]
+}
+
+// WorldToScreen, Camera 类将场景坐标转换为屏幕坐标的方法， ScreenToWorld 的逆变换
+// 参数:
+//     x, y: 场景坐标
+// 返回值:
+//     float32, float32 类型，屏幕像素坐标，原点为镜头画面左上角
+func (c *Camera) WorldToScreen(x, y float32) (float32, float32) {
+	clip := c.GetProjection().Mul4(c.viewMatrix()).Mul4x1(mgl32.Vec4{x, y, 0, 1})
+	ndcX := clip[0] / clip[3]
+	ndcY := clip[1] / clip[3]
+	return (ndcX + 1) / 2 * c.W, (1 - ndcY) / 2 * c.H
+}
+
+// Bounds, Camera 类获取镜头可视范围的方法，缩放或旋转时为可视范围的外接矩形
+// 返回值:
+//     x, y: 可视范围左上角场景坐标
+//     w, h: 可视范围尺寸
+func (c *Camera) Bounds() (x, y, w, h float32) {
+	corners := [4][2]float32{{0, 0}, {c.W, 0}, {0, c.H}, {c.W, c.H}}
+	minX, minY := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	maxX, maxY := -minX, -minY
+	for _, corner := range corners {
+		wx, wy := c.ScreenToWorld(corner[0], corner[1])
+		minX = float32(math.Min(float64(minX), float64(wx)))
+		minY = float32(math.Min(float64(minY), float64(wy)))
+		maxX = float32(math.Max(float64(maxX), float64(wx)))
+		maxY = float32(math.Max(float64(maxY), float64(wy)))
+	}
+	return minX, minY, maxX - minX, maxY - minY
+}
+
+// Update, Camera 类更新镜头震动的方法，应在每次逻辑更新中调用
+// 参数:
+//     delta: 与上次更新的时延
+func (c *Camera) Update(delta float64) {
+	if c.Shake != nil {
+		c.Shake.update(delta)
+	}
+}
+
+// AddTrauma, Camera 类增加镜头震动强度的方法
+// 参数:
+//     amount: 震动强度增量，累计强度取值 [0, 1]
+func (c *Camera) AddTrauma(amount float32) {
+	if c.Shake != nil {
+		c.Shake.AddTrauma(amount)
+	}
+}
+
+// ViewportIn, Camera 类获取镜头在窗口中实际视口的方法
+// 参数:
+//     windowW, windowH: 窗口尺寸
+// 返回值:
+//     Viewport 类，未设置视口时为整个窗口
+func (c *Camera) ViewportIn(windowW, windowH float32) Viewport {
+	if c.Viewport.IsZero() {
+		return Viewport{0, 0, windowW, windowH}
+	}
+	return c.Viewport
+}
+
+// WindowToWorld, Camera 类将窗口坐标转换为场景坐标的方法，先将窗口坐标换算为镜头画面坐标，再经 ScreenToWorld 转换
+// 参数:
+//     x, y: 窗口像素坐标
+//     windowW, windowH: 窗口尺寸
+// 返回值:
+//     float32, float32 类型，场景坐标
+func (c *Camera) WindowToWorld(x, y, windowW, windowH float32) (float32, float32) {
+	vp := c.ViewportIn(windowW, windowH)
+	return c.ScreenToWorld((x-vp.X)*c.W/vp.W, (y-vp.Y)*c.H/vp.H)
+}
+
+// Sees, Camera 类判断形状对象是否在镜头渲染层掩码内的方法
+// 参数:
+//     shape: resolv.Shape 接口对象，未实现 resolv.Layered 接口时视为地形层
+// 返回值:
+//     bool 类型
+func (c *Camera) Sees(shape resolv.Shape) bool {
+	return c.LayerMask.Has(layerOf(shape))
+}
+
+// viewMatrix, Camera 类计算view矩阵的包内方法，依次进行平移、缩放与以镜头画面中心为中心的旋转，并叠加镜头震动
+// 返回值:
+//     mgl32.Mat4 类
+func (c *Camera) viewMatrix() mgl32.Mat4 {
 	target := c.GetPosition().Add(c.front)
 	view := mgl32.LookAtV(c.GetPosition(), target, c.up)
-	return &view[0]
+
+	var shakeX, shakeY, shakeAngle float32
+	if c.Shake != nil {
+		shakeX, shakeY, shakeAngle = c.Shake.offset()
+	}
+	zoom := c.zoom()
+	if zoom == 1 && c.Rotation == 0 && shakeX == 0 && shakeY == 0 && shakeAngle == 0 {
+		return view
+	}
+
+	halfW, halfH := c.W/2, c.H/2
+	transform := mgl32.Translate3D(halfW, halfH, 0).
+		Mul4(mgl32.HomogRotate3DZ(c.Rotation + shakeAngle)).
+		Mul4(mgl32.Scale3D(zoom, zoom, 1)).
+		Mul4(mgl32.Translate3D(-halfW-shakeX, -halfH-shakeY, 0))
+	return transform.Mul4(view)
+}
+
+// zoom, Camera 类获取有效缩放倍数的包内方法
+// 返回值:
+//     float32 类型，未设置或非正数时为 1
+func (c *Camera) zoom() float32 {
+	if c.Zoom <= 0 {
+		return 1
+	}
+	return c.Zoom
 }
 
 // resetScreenSize, Camera 类重置屏幕边界的包内方法
@@ -52,24 +231,50 @@ func (c *Camera) resetScreenSize(width, height float32) {
 	c.H = height
 }
 
-// InPosition, Camera 类根据坐标转换视野的方法
+// InPosition, Camera 类根据坐标转换视野的方法，缩放时按缩放后的可视范围限制在场景内
 // 参数:
 //     x, y: 需转换的坐标
 //     W, H: 场景尺寸
 func (c *Camera) InPosition(x, y, sceneW, sceneH float32) {
-	if x <= 0 {
-		c.X = 0
-	} else if x+c.W > sceneW {
-		c.X = sceneW - c.W
-	} else {
-		c.X = x
+	c.X = clampView(x, c.W, c.zoom(), sceneW)
+	c.Y = clampView(y, c.H, c.zoom(), sceneH)
+}
+
+// clampView, 将镜头坐标限制在场景内的包内函数
+// 参数:
+//     pos: 镜头坐标
+//     size: 镜头尺寸
+//     zoom: 缩放倍数
+//     sceneSize: 场景尺寸
+// 返回值:
+//     float32 类型，限制后的镜头坐标
+func clampView(pos, size, zoom, sceneSize float32) float32 {
+	// 可视范围以镜头画面中心为中心
+	center := pos + size/2
+	half := size / zoom / 2
+	if center-half <= 0 {
+		center = half
+	} else if center+half > sceneSize {
+		center = sceneSize - half
 	}
+	return center - size/2
+}
 
-	if y <= 0 {
-		c.Y = 0
-	} else if y+c.H > sceneH {
-		c.Y = sceneH - c.H
-	} else {
-		c.Y = y
+// StorePrevXY, Camera 类记录当前坐标的方法，应在每次逻辑更新移动镜头前调用
+func (c *Camera) StorePrevXY() {
+	c.prevX, c.prevY = c.X, c.Y
+}
+
+// Lerp, Camera 类将镜头临时移动至上一次与当前逻辑坐标之间插值位置的方法
+// 参数:
+//     alpha: 渲染插值系数
+// 返回值:
+//     func() 类型，将镜头恢复至逻辑坐标的函数
+func (c *Camera) Lerp(alpha float32) func() {
+	x, y := c.X, c.Y
+	c.X = c.prevX + (x-c.prevX)*alpha
+	c.Y = c.prevY + (y-c.prevY)*alpha
+	return func() {
+		c.X, c.Y = x, y
 	}
 }
@@ -0,0 +1,162 @@
+package scene
+
+import "math"
+
+// Damping, 镜头跟随的阻尼方式
+type Damping int
+
+const (
+	// DampingNone, 无阻尼，镜头直接移动至目标位置
+	DampingNone Damping = iota
+	// DampingLerp, 按指数衰减插值趋近目标位置
+	DampingLerp
+	// DampingSpring, 按弹簧模型趋近目标位置
+	DampingSpring
+)
+
+// CameraFollow, 镜头跟随配置对象，定义死区、阻尼、前瞻与平台吸附行为
+type CameraFollow struct {
+	// 死区尺寸，死区以镜头画面中心为中心，目标在死区内移动时镜头不跟随
+	DeadzoneW, DeadzoneH float32
+	// 阻尼方式
+	Damping Damping
+	// Lerp 阻尼的平滑系数，数值越大趋近越快，单位为 1/秒
+	Smoothing float32
+	// Spring 阻尼的弹簧刚度与阻尼系数
+	Stiffness, SpringDamping float32
+	// 前瞻距离，镜头沿目标朝向超前的水平距离
+	Lookahead float32
+	// 前瞻偏移的变化速度，单位为 1/秒
+	LookaheadSmoothing float32
+	// 平台吸附，开启时镜头仅在目标着地或离开垂直死区时调整垂直位置，跳跃时画面不随之上下晃动
+	PlatformSnap bool
+}
+
+// NewDefaultCameraFollow, CameraFollow 类默认实例初始化函数
+// 返回值:
+//     CameraFollow 类指针
+func NewDefaultCameraFollow() *CameraFollow {
+	return &CameraFollow{
+		DeadzoneW:          80,
+		DeadzoneH:          120,
+		Damping:            DampingLerp,
+		Smoothing:          6,
+		Stiffness:          60,
+		SpringDamping:      15,
+		Lookahead:          80,
+		LookaheadSmoothing: 3,
+		PlatformSnap:       true,
+	}
+}
+
+// followState, 镜头跟随的运行状态
+type followState struct {
+	started        bool
+	focusX, focusY float32
+	lookahead      float32
+	velX, velY     float32
+}
+
+// Follow, Camera 类跟随目标移动镜头的方法，未设置 FollowConfig 时镜头直接以目标为中心，
+// 移动后的镜头仍被限制在场景边界内
+// 参数:
+//     targetX, targetY: 目标中心坐标
+//     isXReverse: 目标是否朝向水平向后（左）
+//     onGround: 目标是否着地
+//     delta: 与上次更新的时延
+//     sceneW, sceneH: 场景尺寸
+func (c *Camera) Follow(targetX, targetY float32, isXReverse, onGround bool, delta float64, sceneW, sceneH float32) {
+	cfg := c.FollowConfig
+	if cfg == nil || !c.follow.started {
+		c.SnapTo(targetX, targetY, sceneW, sceneH)
+		return
+	}
+	dt := float32(delta)
+	st := &c.follow
+
+	// 死区：目标越出死区边界时推动焦点
+	st.focusX = pushIntoDeadzone(st.focusX, targetX, cfg.DeadzoneW/2)
+	if cfg.PlatformSnap && onGround {
+		st.focusY = targetY
+	} else {
+		st.focusY = pushIntoDeadzone(st.focusY, targetY, cfg.DeadzoneH/2)
+	}
+
+	// 前瞻：沿朝向平滑偏移
+	lookahead := cfg.Lookahead
+	if isXReverse {
+		lookahead = -lookahead
+	}
+	st.lookahead += (lookahead - st.lookahead) * expFactor(cfg.LookaheadSmoothing, dt)
+
+	goalX := st.focusX + st.lookahead - c.W/2
+	goalY := st.focusY - c.H/2
+	x, y := c.X, c.Y
+
+	switch cfg.Damping {
+	case DampingLerp:
+		t := expFactor(cfg.Smoothing, dt)
+		x += (goalX - x) * t
+		y += (goalY - y) * t
+	case DampingSpring:
+		st.velX += (cfg.Stiffness*(goalX-x) - cfg.SpringDamping*st.velX) * dt
+		st.velY += (cfg.Stiffness*(goalY-y) - cfg.SpringDamping*st.velY) * dt
+		x += st.velX * dt
+		y += st.velY * dt
+	default:
+		x, y = goalX, goalY
+	}
+
+	c.InPosition(x, y, sceneW, sceneH)
+	// 被场景边界限制的方向上清零弹簧速度，避免目标折返时镜头因积累的速度滞留在边界
+	if c.X != x {
+		st.velX = 0
+	}
+	if c.Y != y {
+		st.velY = 0
+	}
+}
+
+// SnapTo, Camera 类使镜头立即以目标为中心并重置跟随状态的方法，用于初始化或目标瞬移（如重生）后
+// 参数:
+//     targetX, targetY: 目标中心坐标
+//     sceneW, sceneH: 场景尺寸
+func (c *Camera) SnapTo(targetX, targetY, sceneW, sceneH float32) {
+	c.follow = followState{
+		started: true,
+		focusX:  targetX,
+		focusY:  targetY,
+	}
+	c.InPosition(targetX-c.W/2, targetY-c.H/2, sceneW, sceneH)
+	c.prevX, c.prevY = c.X, c.Y
+}
+
+// pushIntoDeadzone, 计算目标越出死区后焦点新位置的包内函数
+// 参数:
+//     focus: 当前焦点坐标
+//     target: 目标坐标
+//     halfSize: 死区半宽（或半高）
+// 返回值:
+//     float32 类型，新的焦点坐标
+func pushIntoDeadzone(focus, target, halfSize float32) float32 {
+	if target < focus-halfSize {
+		return target + halfSize
+	}
+	if target > focus+halfSize {
+		return target - halfSize
+	}
+	return focus
+}
+
+// expFactor, 计算与帧率无关的指数衰减插值系数的包内函数
+// 参数:
+//     rate: 趋近速度，单位为 1/秒
+//     dt: 时延
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func expFactor(rate, dt float32) float32 {
+	if rate <= 0 {
+		return 1
+	}
+	return 1 - float32(math.Exp(float64(-rate*dt)))
+}
@@ -0,0 +1,113 @@
+package scene
+
+import "testing"
+
+const (
+	sceneW, sceneH = 2000, 1000
+	followDelta    = 1.0 / 60
+)
+
+// newFollowCamera, 构建以 (1000, 500) 为中心、无阻尼与前瞻的跟随镜头
+func newFollowCamera(cfg *CameraFollow) *Camera {
+	c := NewDefaultCamera(0, 0, 320, 240)
+	c.FollowConfig = cfg
+	c.SnapTo(1000, 500, sceneW, sceneH)
+	return c
+}
+
+func TestCameraFollowDeadzone(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetX      float32
+		targetY      float32
+		onGround     bool
+		platformSnap bool
+		wantX, wantY float32
+	}{
+		{"inside deadzone", 1030, 550, false, false, 840, 380},
+		{"past right edge", 1100, 500, false, false, 890, 380},
+		{"past left edge", 900, 500, false, false, 790, 380},
+		{"past bottom edge", 1000, 600, false, false, 840, 420},
+		{"past top edge", 1000, 400, false, false, 840, 340},
+		{"platform snap airborne", 1000, 550, false, true, 840, 380},
+		{"platform snap grounded", 1000, 550, true, true, 840, 430},
+	}
+	for _, tt := range tests {
+		c := newFollowCamera(&CameraFollow{DeadzoneW: 100, DeadzoneH: 120, PlatformSnap: tt.platformSnap})
+		c.Follow(tt.targetX, tt.targetY, false, tt.onGround, followDelta, sceneW, sceneH)
+		if c.X != tt.wantX || c.Y != tt.wantY {
+			t.Errorf("%s: camera at (%v, %v), want (%v, %v)", tt.name, c.X, c.Y, tt.wantX, tt.wantY)
+		}
+	}
+}
+
+func TestCameraFollowLookahead(t *testing.T) {
+	c := newFollowCamera(&CameraFollow{Lookahead: 80})
+	c.Follow(1000, 500, false, true, followDelta, sceneW, sceneH)
+	if c.X != 920 {
+		t.Errorf("facing right: camera x %v, want 920", c.X)
+	}
+	c.Follow(1000, 500, true, true, followDelta, sceneW, sceneH)
+	if c.X != 760 {
+		t.Errorf("facing left: camera x %v, want 760", c.X)
+	}
+
+	// 平滑前瞻逐步趋近，不越过前瞻距离
+	c = newFollowCamera(&CameraFollow{Lookahead: 80, LookaheadSmoothing: 3})
+	prev := c.X
+	for i := 0; i < 120; i++ {
+		c.Follow(1000, 500, false, true, followDelta, sceneW, sceneH)
+		if c.X < prev || c.X > 920 {
+			t.Fatalf("step %d: camera x %v after %v, want increasing up to 920", i, c.X, prev)
+		}
+		prev = c.X
+	}
+	if prev < 915 {
+		t.Errorf("lookahead did not settle: camera x %v", prev)
+	}
+}
+
+func TestCameraFollowBounds(t *testing.T) {
+	tests := []struct {
+		name             string
+		cfg              *CameraFollow
+		targetX, targetY float32
+		isXReverse       bool
+		wantX, wantY     float32
+	}{
+		{"no config top left", nil, 10, 10, false, 0, 0},
+		{"no config bottom right", nil, 1990, 990, false, 1680, 760},
+		{"deadzone top left", &CameraFollow{DeadzoneW: 100, DeadzoneH: 120}, 0, 0, false, 0, 0},
+		{"lookahead past left edge", &CameraFollow{Lookahead: 80}, 100, 500, true, 0, 380},
+		{"lookahead past right edge", &CameraFollow{Lookahead: 80}, 1900, 500, false, 1680, 380},
+		{"spring bottom right", &CameraFollow{Damping: DampingSpring, Stiffness: 60, SpringDamping: 15}, 2000, 1000, false, 1680, 760},
+	}
+	for _, tt := range tests {
+		c := newFollowCamera(tt.cfg)
+		for i := 0; i < 600; i++ {
+			c.Follow(tt.targetX, tt.targetY, tt.isXReverse, false, followDelta, sceneW, sceneH)
+			if c.X < 0 || c.Y < 0 || c.X > sceneW-c.W || c.Y > sceneH-c.H {
+				t.Fatalf("%s: camera left the scene at (%v, %v)", tt.name, c.X, c.Y)
+			}
+		}
+		if c.X != tt.wantX || c.Y != tt.wantY {
+			t.Errorf("%s: camera at (%v, %v), want (%v, %v)", tt.name, c.X, c.Y, tt.wantX, tt.wantY)
+		}
+	}
+}
+
+func TestCameraSpringClampedVelocity(t *testing.T) {
+	c := newFollowCamera(&CameraFollow{Damping: DampingSpring, Stiffness: 60, SpringDamping: 15})
+	for i := 0; i < 600; i++ {
+		c.Follow(2000, 1000, false, false, followDelta, sceneW, sceneH)
+	}
+	if c.X != 1680 || c.Y != 760 || c.follow.velX != 0 || c.follow.velY != 0 {
+		t.Fatalf("clamped camera at (%v, %v) with velocity (%v, %v)", c.X, c.Y, c.follow.velX, c.follow.velY)
+	}
+
+	// 目标折返时镜头立即离开边界
+	c.Follow(1000, 500, false, false, followDelta, sceneW, sceneH)
+	if c.X >= 1680 || c.Y >= 760 {
+		t.Errorf("camera stuck at the edge: (%v, %v)", c.X, c.Y)
+	}
+}
@@ -0,0 +1,89 @@
+package scene
+
+import "math"
+
+// CameraShake, 镜头震动对象，震动幅度与震动强度（trauma）的平方成正比，强度随时间线性衰减
+type CameraShake struct {
+	// 最大平移偏移量，单位为像素
+	MaxOffset float32
+	// 最大旋转偏移，单位为弧度
+	MaxAngle float32
+	// 震动强度每秒衰减量
+	Decay float32
+	// 震动频率，单位为赫兹
+	Frequency float32
+
+	trauma float32
+	time   float64
+}
+
+// NewDefaultCameraShake, CameraShake 类默认实例初始化函数
+// 返回值:
+//     CameraShake 类指针
+func NewDefaultCameraShake() *CameraShake {
+	return &CameraShake{
+		MaxOffset: 16,
+		MaxAngle:  0.05,
+		Decay:     1.5,
+		Frequency: 25,
+	}
+}
+
+// AddTrauma, CameraShake 类增加震动强度的方法
+// 参数:
+//     amount: 震动强度增量，累计强度取值 [0, 1]
+func (s *CameraShake) AddTrauma(amount float32) {
+	s.trauma += amount
+	if s.trauma > 1 {
+		s.trauma = 1
+	} else if s.trauma < 0 {
+		s.trauma = 0
+	}
+}
+
+// Trauma, CameraShake 类获取当前震动强度的方法
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func (s *CameraShake) Trauma() float32 {
+	return s.trauma
+}
+
+// update, CameraShake 类推进震动时间并衰减震动强度的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (s *CameraShake) update(delta float64) {
+	if s.trauma <= 0 {
+		return
+	}
+	s.time += delta
+	s.AddTrauma(-s.Decay * float32(delta))
+}
+
+// offset, CameraShake 类计算当前震动偏移的包内方法，
+// 偏移由震动时间决定而非随机数，保证输入回放时鼠标坐标换算结果一致
+// 返回值:
+//     x, y: 平移偏移量
+//     angle: 旋转偏移
+func (s *CameraShake) offset() (x, y, angle float32) {
+	if s.trauma <= 0 {
+		return 0, 0, 0
+	}
+	shake := s.trauma * s.trauma
+	t := s.time * float64(s.Frequency)
+	x = s.MaxOffset * shake * noise(t, 0)
+	y = s.MaxOffset * shake * noise(t, 1)
+	angle = s.MaxAngle * shake * noise(t, 2)
+	return x, y, angle
+}
+
+// noise, 计算平滑伪随机噪声的包内函数
+// 参数:
+//     t: 采样时间
+//     seed: 噪声通道，不同通道之间互不相关
+// 返回值:
+//     float32 类型，取值 [-1, 1]
+func noise(t float64, seed int) float32 {
+	phase := float64(seed) * 12.9898
+	v := math.Sin(t+phase)*0.5 + math.Sin(t*2.31+phase*1.7)*0.3 + math.Sin(t*4.79+phase*2.3)*0.2
+	return float32(v)
+}
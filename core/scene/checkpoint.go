@@ -0,0 +1,211 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/event"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+)
+
+// Checkpoint, 存档点对象，作为含 "checkpoint" 标签的触发区域形状对象的 Data，玩家角色接触时激活
+type Checkpoint struct {
+	ID        string
+	Activated bool
+}
+
+// CheckpointState, 存档点记录对象，记录玩家角色重生时恢复的位置与状态
+type CheckpointState struct {
+	ID         string
+	X, Y       int32
+	IsXReverse bool
+	// 重生时的生命值，不大于 0 时恢复至最大生命值
+	HP int
+}
+
+// NewCheckpoint, 存档点触发区域初始化函数，触发区域不渲染，重生位置为触发区域底部中央
+// 参数:
+//     id: 存档点标识
+//     x, y: 触发区域坐标
+//     w, h: 触发区域尺寸
+// 返回值:
+//     resolv.Rectangle 类指针
+func NewCheckpoint(id string, x, y, w, h int32) *resolv.Rectangle {
+	r := resolv.NewRectangle(x, y, w, h, 0, 1, nil, nil)
+	r.AddTags("checkpoint", "hide")
+	r.SetData(&Checkpoint{ID: id})
+	return r
+}
+
+// RespawnConfig, 重生配置对象
+type RespawnConfig struct {
+	// 角色死亡后至开始淡出的等待时长，单位为秒
+	Delay float64
+	// 淡出与淡入时长，单位为秒
+	FadeOut, FadeIn float64
+}
+
+// NewDefaultRespawnConfig, RespawnConfig 类默认实例初始化函数
+// 返回值:
+//     RespawnConfig 类指针
+func NewDefaultRespawnConfig() *RespawnConfig {
+	return &RespawnConfig{
+		Delay:   1,
+		FadeOut: 0.5,
+		FadeIn:  0.5,
+	}
+}
+
+// respawnState, 重生流程的运行状态
+type respawnState struct {
+	// 已收到角色死亡事件，等待重生
+	dying bool
+	// 角色死亡后经过的时长，未死亡时为 0
+	deadTime float64
+	// 重生后经过的时长，用于淡入，小于 0 为未处于淡入过程
+	fadeInTime float64
+}
+
+// Respawn, Scene 类使玩家角色在最近激活的存档点重生的方法，无存档点时在场景初始位置重生
+func (s *Scene) Respawn() {
+	cp := s.Checkpoint
+	if cp == nil {
+		return
+	}
+	s.Player.SetXY(cp.X, cp.Y)
+	s.Player.StorePrevXY()
+	s.Player.IsXReverse = cp.IsXReverse
+	s.Player.Revive(cp.HP)
+	for _, c := range s.cameras() {
+		px, py := s.Player.Center()
+		c.SnapTo(float32(px), float32(py), s.W, s.H)
+	}
+	s.respawn = respawnState{}
+	s.emit(&PlayerRespawned{Player: s.Player, Checkpoint: cp})
+}
+
+// Reset, Scene 类重置关卡的方法，清空场景空间与实体世界后重新调用 Init 构建关卡，不会重复加载资源；
+// 来自对象池的形状对象归还对象池，事件总线的订阅与待派发事件一并清空，
+// 场景自身的订阅随即恢复，其余订阅应在 Init 中重新进行
+func (s *Scene) Reset() {
+	for _, shape := range s.removed {
+		if p, ok := shape.(pooled); ok {
+			p.release()
+		}
+	}
+	if s.Map != nil {
+		for _, shape := range *s.Map {
+			if p, ok := shape.(pooled); ok {
+				p.release()
+			}
+		}
+		s.Map.Clear()
+	}
+	if s.Events != nil {
+		s.Events.Clear()
+		s.subscribeRespawn()
+	}
+	if s.World != nil {
+		s.World.Clear()
+	}
+	s.Checkpoint = nil
+	s.respawn = respawnState{}
+	s.removed = nil
+	s.Init()
+	s.initWorld()
+}
+
+// updateCheckpoints, Scene 类激活玩家角色接触的存档点的包内方法
+func (s *Scene) updateCheckpoints() {
+	if s.Checkpoint == nil {
+		// 以角色初始位置作为默认重生位置
+		s.Checkpoint = &CheckpointState{ID: "", X: s.Player.X, Y: s.Player.Y, IsXReverse: s.Player.IsXReverse}
+	}
+	if s.Player.HasTags("isDead") {
+		return
+	}
+	colliding := s.Map.FilterByTags("checkpoint").GetCollidingShapes(s.Player)
+	for i := 0; i < colliding.Length(); i++ {
+		shape := colliding.Get(i)
+		cp, ok := shape.GetData().(*Checkpoint)
+		if !ok || (cp.Activated && s.Checkpoint.ID == cp.ID) {
+			continue
+		}
+		cp.Activated = true
+		x1, _ := shape.GetXY()
+		x2, y2 := shape.GetXY2()
+		s.Checkpoint = &CheckpointState{
+			ID:         cp.ID,
+			X:          (x1+x2)/2 - s.Player.W/2,
+			Y:          y2 - s.Player.H,
+			IsXReverse: s.Player.IsXReverse,
+		}
+		if s.OnCheckpoint != nil {
+			s.OnCheckpoint(cp)
+		}
+		s.emit(&CheckpointActivated{Checkpoint: cp, State: s.Checkpoint})
+	}
+}
+
+// updateRespawn, Scene 类推进死亡淡出与重生淡入流程的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateRespawn(delta float64) {
+	cfg := s.RespawnConfig
+	if cfg == nil {
+		return
+	}
+	if s.respawn.fadeInTime >= 0 {
+		s.respawn.fadeInTime += delta
+		if s.respawn.fadeInTime >= cfg.FadeIn {
+			s.respawn.fadeInTime = -1
+		}
+	}
+	if !s.respawn.dying {
+		return
+	}
+	s.respawn.deadTime += delta
+	if s.respawn.deadTime >= cfg.Delay+cfg.FadeOut {
+		s.Respawn()
+	}
+}
+
+// subscribeRespawn, Scene 类订阅角色死亡事件以开始重生流程的包内方法，事件总线清空后需重新订阅
+func (s *Scene) subscribeRespawn() {
+	if s.Events == nil {
+		return
+	}
+	s.Events.Subscribe(EventPlayerDied, func(e event.Event) {
+		s.respawn.dying = true
+	})
+}
+
+// brightness, Scene 类获取当前画面亮度的包内方法，用于死亡淡出与重生淡入
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func (s *Scene) brightness() float32 {
+	cfg := s.RespawnConfig
+	if cfg == nil {
+		return 1
+	}
+	if s.respawn.deadTime > cfg.Delay {
+		return 1 - fadeRatio(s.respawn.deadTime-cfg.Delay, cfg.FadeOut)
+	}
+	if s.respawn.fadeInTime >= 0 {
+		return fadeRatio(s.respawn.fadeInTime, cfg.FadeIn)
+	}
+	return 1
+}
+
+// fadeRatio, 计算淡入淡出进度的包内函数
+// 参数:
+//     t: 已经过时长
+//     duration: 总时长
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func fadeRatio(t, duration float64) float32 {
+	if duration <= 0 || t >= duration {
+		return 1
+	}
+	if t <= 0 {
+		return 0
+	}
+	return float32(t / duration)
+}
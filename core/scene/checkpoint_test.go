@@ -0,0 +1,124 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/event"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+func TestResetReleasesPooledShapes(t *testing.T) {
+	s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), func() {})
+	s.Player = NewPlayer(0, 0, 16, 32, 0.5, 1, nil, nil)
+	s.Init = func() { s.Map.Add(s.Player) }
+	s.Init()
+
+	enemies := NewEnemyPool(4, func() *Enemy { return NewEnemy(0, 0, 16, 16, 1) })
+	spawner := NewSpawner(500, 400, 0, 2, enemies)
+	s.Spawners = []*Spawner{spawner}
+	s.updateSpawners(0)
+	s.updateSpawners(0)
+
+	lw := NewFireBolt()
+	bolt := lw.newBolt(0, 0, mgl32.Vec2{1, 0}, false)
+	s.Map.Add(bolt)
+	queued := lw.newBolt(0, 0, mgl32.Vec2{1, 0}, false)
+	s.Map.Add(queued)
+	s.Remove(queued)
+
+	delivered := 0
+	sub := s.Events.Subscribe(EventEnemyDied, func(e event.Event) { delivered++ })
+	s.emit(&EnemyDied{})
+
+	if spawner.Alive() != 2 || enemies.Len() != 0 || lw.Pool.Len() != 0 {
+		t.Fatalf("before reset: %d alive, %d free enemies, %d free bolts", spawner.Alive(), enemies.Len(), lw.Pool.Len())
+	}
+	s.Reset()
+	if spawner.Alive() != 0 || enemies.Len() != 2 || lw.Pool.Len() != 2 {
+		t.Errorf("after reset: %d alive, %d free enemies, %d free bolts", spawner.Alive(), enemies.Len(), lw.Pool.Len())
+	}
+	if s.Events.Pending() != 0 || sub.Active() {
+		t.Errorf("after reset: %d pending events, subscription active %v", s.Events.Pending(), sub.Active())
+	}
+	s.Events.Flush()
+	if delivered != 0 {
+		t.Errorf("event queued before reset was delivered %d times", delivered)
+	}
+	if s.Map.Length() != 1 || !s.Map.Contains(s.Player) {
+		t.Errorf("after reset the scene has %d shapes", s.Map.Length())
+	}
+}
+
+func TestRespawnFollowsDeathEvent(t *testing.T) {
+	s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), func() {})
+	s.Player = NewPlayer(100, 50, 16, 32, 0.5, 1, nil, nil)
+	s.Map.Add(s.Player)
+	s.RespawnConfig = &RespawnConfig{Delay: 0.5, FadeOut: 0.25, FadeIn: 0.25}
+	s.Checkpoint = &CheckpointState{X: 10, Y: 20, HP: 2}
+
+	respawned := 0
+	s.Events.Subscribe(EventPlayerRespawned, func(e event.Event) { respawned++ })
+
+	// 死亡标签本身不会触发重生流程
+	s.Player.AddTags("isDead")
+	s.updateRespawn(1)
+	if s.respawn.deadTime != 0 {
+		t.Fatalf("respawn started without a death event")
+	}
+	s.Player.RemoveTags("isDead")
+
+	if !s.hurtPlayer(ecs.Damage{Amount: 1000}) || !s.Player.HasTags("isDead") {
+		t.Fatalf("player did not die")
+	}
+	s.updateRespawn(1)
+	if s.respawn.deadTime != 0 {
+		t.Fatalf("respawn started before the death event was flushed")
+	}
+	s.Events.Flush()
+	s.updateRespawn(0.5)
+	if !s.Player.HasTags("isDead") {
+		t.Fatalf("respawned before delay and fade out")
+	}
+	s.updateRespawn(0.25)
+	s.Events.Flush()
+	if s.Player.HasTags("isDead") || s.Player.X != 10 || s.Player.Y != 20 || respawned != 1 {
+		t.Errorf("after respawn: dead %v, at (%d, %d), %d respawn events", s.Player.HasTags("isDead"), s.Player.X, s.Player.Y, respawned)
+	}
+
+	// 重置关卡后场景自身的订阅随即恢复
+	s.Init = func() { s.Map.Add(s.Player) }
+	s.Reset()
+	s.Checkpoint = &CheckpointState{X: 30, Y: 40}
+	s.hurtPlayer(ecs.Damage{Amount: 1000})
+	s.Events.Flush()
+	s.updateRespawn(0.75)
+	if s.Player.HasTags("isDead") || s.Player.X != 30 {
+		t.Errorf("no respawn after reset")
+	}
+}
+
+func TestRestartActionResets(t *testing.T) {
+	s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), nil)
+	s.Player = NewPlayer(0, 0, 16, 32, 0.5, 1, nil, nil)
+	inits := 0
+	s.Init = func() {
+		inits++
+		s.Player.SetXY(100, 50)
+		s.Map.Add(s.Player)
+	}
+	s.Init()
+	s.Player.SetXY(300, 50)
+
+	s.Input.SetKeyDown(input.KeyR)
+	s.Update(0.125)
+	if inits != 2 || s.Player.X != 100 || s.Map.Length() != 1 {
+		t.Fatalf("after restart: %d inits, player at %d, %d shapes", inits, s.Player.X, s.Map.Length())
+	}
+	// 按住不放不会重复重置
+	s.Update(0.125)
+	if inits != 2 {
+		t.Errorf("held restart reset the scene again")
+	}
+}
@@ -0,0 +1,141 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Hazard, 伤害定义对象，作为形状对象的 Data 挂载于尖刺、火球等会造成伤害的形状对象上
+type Hazard struct {
+	// 伤害数值
+	Damage int
+	// 击退力度，击退方向为伤害来源指向受伤者
+	Knockback float32
+	// 伤害发起者，发起者不会受到自身造成的伤害
+	Owner interface{}
+	// 所属阵营，不会伤害同阵营的角色，为 TeamNone 时可伤害任意角色
+	Team Team
+}
+
+// Team, 阵营
+type Team int
+
+const (
+	// 无阵营
+	TeamNone Team = iota
+	// 玩家阵营
+	TeamPlayer
+	// 敌人阵营
+	TeamEnemy
+)
+
+// teamOf, 获取角色所属阵营的包内函数
+// 参数:
+//     v: 角色对象
+// 返回值:
+//     Team 类型，非玩家角色或敌人时为 TeamNone
+func teamOf(v interface{}) Team {
+	switch v.(type) {
+	case *Player:
+		return TeamPlayer
+	case *Enemy:
+		return TeamEnemy
+	}
+	return TeamNone
+}
+
+// sameTeam, 判断攻击作用形状对象与目标是否同属一个阵营的包内函数
+// 参数:
+//     source: 攻击作用形状对象
+//     target: 目标形状对象
+// 返回值:
+//     bool 类型，攻击作用形状对象未挂载 Hazard 或无阵营时为 false
+func sameTeam(source, target resolv.Shape) bool {
+	h, ok := source.GetData().(*Hazard)
+	return ok && h.Team != TeamNone && h.Team == teamOf(target)
+}
+
+// DefaultHazard, 未挂载 Hazard 的 "dangerous" 形状对象使用的伤害定义
+var DefaultHazard = Hazard{Damage: 1, Knockback: 8}
+
+// hazardOf, 获取形状对象伤害定义的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     Hazard 类指针，形状对象未挂载 Hazard 时，含 "dangerous" 标签的返回 DefaultHazard，否则为 nil
+func hazardOf(shape resolv.Shape) *Hazard {
+	if h, ok := shape.GetData().(*Hazard); ok {
+		return h
+	}
+	if shape.HasTags("dangerous") {
+		h := DefaultHazard
+		return &h
+	}
+	return nil
+}
+
+// setHazardOwner, 设置攻击作用形状对象伤害发起者的包内函数，发起者及其同阵营角色不会受到其伤害
+// 参数:
+//     shape: resolv.Shape 接口对象，攻击作用形状对象，可为 nil
+//     owner: 伤害发起者
+func setHazardOwner(shape resolv.Shape, owner interface{}) {
+	if shape == nil {
+		return
+	}
+	if h, ok := shape.GetData().(*Hazard); ok {
+		h.Owner = owner
+		h.Team = teamOf(owner)
+	}
+}
+
+// damageFrom, 根据伤害定义生成伤害事件的包内函数，击退方向为伤害来源中心指向受伤者中心并略微向上
+// 参数:
+//     h: Hazard 类指针
+//     source: 伤害来源形状对象
+//     target: 受伤形状对象
+// 返回值:
+//     ecs.Damage 类
+func damageFrom(h *Hazard, source, target resolv.Shape) ecs.Damage {
+	sx, sy := shapeCenter(source)
+	tx, ty := shapeCenter(target)
+	dir := mgl32.Vec2{tx - sx, ty - sy}
+	if dir.Len() == 0 {
+		dir = mgl32.Vec2{0, -1}
+	}
+	dir = dir.Normalize().Add(mgl32.Vec2{0, -0.5}).Normalize()
+	return ecs.Damage{
+		Amount:     h.Damage,
+		Source:     source,
+		KnockbackX: dir[0] * h.Knockback,
+		KnockbackY: dir[1] * h.Knockback,
+	}
+}
+
+// shapeCenter, 获取形状对象中心坐标的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     float32, float32 类型
+func shapeCenter(shape resolv.Shape) (float32, float32) {
+	x1, y1 := shape.GetXY()
+	x2, y2 := shape.GetXY2()
+	return float32(x1+x2) / 2, float32(y1+y2) / 2
+}
+
+// visibility, 可临时隐藏的形状接口对象，如处于无敌闪烁中的角色
+type visibility interface {
+	Visible() bool
+}
+
+// isVisible, 判断形状对象当前是否应渲染的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     bool 类型，未实现 visibility 接口时始终为 true
+func isVisible(shape resolv.Shape) bool {
+	if v, ok := shape.(visibility); ok {
+		return v.Visible()
+	}
+	return true
+}
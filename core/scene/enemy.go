@@ -0,0 +1,181 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/pool"
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Behaviour, 敌人行为接口对象，每次更新时决定敌人的移动速度与攻击
+type Behaviour interface {
+	Update(s *Scene, e *Enemy, delta float64)
+}
+
+// Damageable, 可受伤形状接口对象
+type Damageable interface {
+	TakeDamage(d ecs.Damage) bool
+}
+
+// Enemy, 敌人角色对象，以方形作为形状对象，接触玩家角色时造成伤害，行为由 Behaviour 决定
+type Enemy struct {
+	resolv.Rectangle
+	Health *ecs.Health
+	Weapon Weapon
+	AtkVec mgl32.Vec2
+	// 敌人行为，为 nil 时敌人原地不动
+	Behaviour Behaviour
+	// 是否飞行，飞行敌人不受重力影响
+	Flying bool
+	// 重力倍率，与场景重力加速度相乘
+	GravityScale float32
+	// 受击后的硬直时长，硬直期间不执行行为，保留击退速度
+	StunTime float64
+	Clip     *AnimationClip
+	animator Animator
+	stun     float64
+	onGround bool
+	// 所属对象池与生成器，为 nil 时移除后不回收
+	pool    *pool.Pool
+	spawner *Spawner
+}
+
+// NewEnemy, Enemy 类实例初始化函数
+// 参数:
+//     x, y: 敌人坐标
+//     w, h: 敌人长宽尺寸
+//     maxHP: 最大生命值
+//     frames: 动画 Texture 对象名列表
+// 返回值:
+//     Enemy 类指针
+func NewEnemy(x, y, w, h int32, maxHP int, frames ...string) *Enemy {
+	r := resolv.NewRectangle(x, y, w, h, 0.5, 1, nil, resource.GetTexturesByName(frames...))
+	e := &Enemy{
+		Rectangle:    *r,
+		Health:       ecs.NewHealth(maxHP),
+		GravityScale: 1,
+		StunTime:     0.2,
+		Clip:         &AnimationClip{Frames: resource.GetTexturesByName(frames...), FrameTime: 0.08, Loop: true},
+	}
+	e.Health.IFrames = 0.1
+	e.SetLayer(render.LayerActors)
+	e.SetData(&Hazard{Damage: 1, Knockback: 8, Owner: e, Team: TeamEnemy})
+	e.animator.Play(e.Clip)
+	return e
+}
+
+// TakeDamage, Enemy 类受到伤害的方法， Damageable.TakeDamage(d ecs.Damage) bool 的实现，伤害生效时敌人被击退并进入硬直
+// 参数:
+//     d: ecs.Damage 类，伤害事件
+// 返回值:
+//     bool 类型， true 为伤害生效
+func (e *Enemy) TakeDamage(d ecs.Damage) bool {
+	if !e.Health.TakeDamage(d) {
+		return false
+	}
+	e.SpeedX += d.KnockbackX
+	e.SpeedY += d.KnockbackY
+	e.stun = e.StunTime
+	return true
+}
+
+// Attack, Enemy 类朝攻击矢量方向攻击的方法
+// 返回值:
+//     AttackResult 类指针，武器攻击结果，无武器或冷却中时为 nil
+func (e *Enemy) Attack() *AttackResult {
+	if e.Weapon == nil {
+		return nil
+	}
+	x, y := e.Center()
+	return e.Weapon.Attack(x, y, e.AtkVec, e.IsXReverse)
+}
+
+// Visible, Enemy 类判断敌人当前是否应渲染的方法，无敌闪烁时间隔隐藏
+// 返回值:
+//     bool 类型
+func (e *Enemy) Visible() bool {
+	return e.Health.Visible()
+}
+
+// OnGround, Enemy 类判断敌人是否着陆的方法
+// 返回值:
+//     bool 类型
+func (e *Enemy) OnGround() bool {
+	return e.onGround
+}
+
+// updateEnemies, Scene 类更新场景内全部敌人的包内方法，移除已死亡的敌人
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateEnemies(delta float64) {
+	// 复用临时列表，避免每次更新分配内存
+	enemies := s.enemies[:0]
+	for _, shape := range *s.Map {
+		if e, ok := shape.(*Enemy); ok {
+			enemies = append(enemies, e)
+		}
+	}
+
+	for _, e := range enemies {
+		if e.Health.IsDead() {
+			s.emit(&EnemyDied{Enemy: e})
+			s.Remove(e)
+			continue
+		}
+		s.updateEnemy(e, delta)
+	}
+	s.enemies = enemies[:0]
+}
+
+// updateEnemy, Scene 类更新单个敌人的包内方法，执行行为后按场景内 "solid" 形状对象做阻挡判定
+// 参数:
+//     e: Enemy 类指针
+//     delta: 与上次更新的时延
+func (s *Scene) updateEnemy(e *Enemy, delta float64) {
+	e.Health.Tick(delta)
+	if e.Weapon != nil {
+		e.Weapon.CoolDown(delta)
+	}
+	if !e.Flying {
+		e.SpeedY += s.Gravity * e.GravityScale
+	}
+
+	// 默认朝向移动方向，行为可在更新时覆盖朝向
+	if e.SpeedX < 0 {
+		e.IsXReverse = true
+	} else if e.SpeedX > 0 {
+		e.IsXReverse = false
+	}
+
+	if e.stun > 0 {
+		e.stun -= delta
+	} else if e.Behaviour != nil {
+		e.Behaviour.Update(s, e, delta)
+	}
+
+	solids := s.Map.Filter(func(shape resolv.Shape) bool {
+		return shape != resolv.Shape(e) && shape.HasTags("solid") && !shape.HasTags("destroyed")
+	})
+	x := int32(e.SpeedX)
+	y := int32(e.SpeedY)
+	if res := solids.Resolve(e, x, 0); res.Colliding() {
+		x = res.ResolveX
+		e.SpeedX = 0
+	}
+	e.X += x
+	if res := solids.Resolve(e, 0, y); res.Colliding() {
+		y = res.ResolveY
+		e.SpeedY = 0
+	}
+	e.Y += y
+	down := solids.Resolve(e, 0, 1)
+	e.onGround = down.Colliding()
+
+	e.animator.Play(e.Clip)
+	e.animator.Update(float32(delta))
+	if texture := e.animator.Texture(); texture != nil {
+		e.Texture = texture
+	}
+}
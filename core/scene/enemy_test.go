@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+func TestEnemyGravity(t *testing.T) {
+	tests := []struct {
+		name    string
+		gravity float32
+		scale   float32
+		flying  bool
+		want    float32
+	}{
+		{"default", DefaultGravity, 1, false, 0.5},
+		{"scene gravity", 2, 1, false, 2},
+		{"scaled", 2, 0.25, false, 0.5},
+		{"flying", 2, 1, true, 0},
+	}
+	for _, tt := range tests {
+		s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), func() {})
+		s.Gravity = tt.gravity
+		e := NewEnemy(0, 0, 16, 16, 1)
+		e.GravityScale = tt.scale
+		e.Flying = tt.flying
+		s.Map.Add(e)
+		s.updateEnemy(e, 0.125)
+		s.updateEnemy(e, 0.125)
+		if e.SpeedY != tt.want*2 {
+			t.Errorf("%s: speed %v after two updates, want %v", tt.name, e.SpeedY, tt.want*2)
+		}
+	}
+}
+
+func TestHazardTeam(t *testing.T) {
+	s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), func() {})
+	s.Player = NewPlayer(0, 0, 16, 32, 0.5, 1, nil, nil)
+	shooter := NewEnemy(0, 0, 16, 16, 3)
+	other := NewEnemy(0, 0, 16, 16, 3)
+	s.Map.Add(s.Player, shooter, other)
+	s.initWorld()
+
+	lw := NewFireBolt()
+	fire := func(owner resolv.Shape) *Projectile {
+		bolt := lw.newBolt(8, 8, mgl32.Vec2{1, 0}, false)
+		s.applyAttack(owner, &AttackResult{Shapes: []resolv.Shape{bolt}})
+		return bolt
+	}
+
+	enemyBolt := fire(shooter)
+	if hits := s.damageEntities(enemyBolt, nil); len(hits) != 0 {
+		t.Errorf("enemy bolt hit %d targets, want none", len(hits))
+	}
+	if other.Health.HP != other.Health.MaxHP {
+		t.Errorf("enemy bolt damaged another enemy")
+	}
+	hp := s.Player.Health.HP
+	s.damagePlayer(enemyBolt)
+	if s.Player.Health.HP >= hp {
+		t.Errorf("enemy bolt did not damage the player")
+	}
+
+	playerBolt := fire(s.Player)
+	if hits := s.damageEntities(playerBolt, nil); len(hits) != 2 {
+		t.Errorf("player bolt hit %d enemies, want 2", len(hits))
+	}
+
+	// 敌人的接触伤害同样不会伤害同阵营的敌人
+	if canHit(shooter, other) || !canHit(shooter, s.Player) {
+		t.Errorf("enemy contact damage ignores teams")
+	}
+}
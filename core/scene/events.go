@@ -0,0 +1,118 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/event"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+)
+
+// 场景事件类型名称，场景事件于每次更新结束、移除销毁的形状对象之前统一派发
+const (
+	EventPlayerDamaged       event.Type = "player_damaged"
+	EventPlayerDied          event.Type = "player_died"
+	EventPlayerRespawned     event.Type = "player_respawned"
+	EventEnemyDied           event.Type = "enemy_died"
+	EventProjectileHit       event.Type = "projectile_hit"
+	EventWeaponPickedUp      event.Type = "weapon_picked_up"
+	EventCheckpointActivated event.Type = "checkpoint_activated"
+	EventAbilityActivated    event.Type = "ability_activated"
+)
+
+// PlayerDamaged, 玩家角色受到伤害事件
+type PlayerDamaged struct {
+	Player *Player
+	Damage ecs.Damage
+}
+
+// EventType, PlayerDamaged 类 event.Event.EventType() event.Type 的实现
+func (e *PlayerDamaged) EventType() event.Type { return EventPlayerDamaged }
+
+// PlayerDied, 玩家角色死亡事件
+type PlayerDied struct {
+	Player *Player
+	Damage ecs.Damage
+}
+
+// EventType, PlayerDied 类 event.Event.EventType() event.Type 的实现
+func (e *PlayerDied) EventType() event.Type { return EventPlayerDied }
+
+// PlayerRespawned, 玩家角色重生事件
+type PlayerRespawned struct {
+	Player     *Player
+	Checkpoint *CheckpointState
+}
+
+// EventType, PlayerRespawned 类 event.Event.EventType() event.Type 的实现
+func (e *PlayerRespawned) EventType() event.Type { return EventPlayerRespawned }
+
+// EnemyDied, 敌人死亡事件，派发后敌人从场景中移除
+type EnemyDied struct {
+	Enemy *Enemy
+}
+
+// EventType, EnemyDied 类 event.Event.EventType() event.Type 的实现
+func (e *EnemyDied) EventType() event.Type { return EventEnemyDied }
+
+// ProjectileHit, 投射物命中事件，派发后销毁的投射物方才归还对象池，处理函数不应保留投射物
+type ProjectileHit struct {
+	Projectile *Projectile
+	// 命中目标，撞击 "solid" 形状对象时为 nil
+	Target resolv.Shape
+	// 命中时投射物的坐标
+	X, Y int32
+}
+
+// EventType, ProjectileHit 类 event.Event.EventType() event.Type 的实现
+func (e *ProjectileHit) EventType() event.Type { return EventProjectileHit }
+
+// WeaponPickedUp, 玩家角色拾取武器事件
+type WeaponPickedUp struct {
+	Slot *WeaponSlot
+	// 武器所在槽位
+	Index int
+}
+
+// EventType, WeaponPickedUp 类 event.Event.EventType() event.Type 的实现
+func (e *WeaponPickedUp) EventType() event.Type { return EventWeaponPickedUp }
+
+// CheckpointActivated, 存档点激活事件
+type CheckpointActivated struct {
+	Checkpoint *Checkpoint
+	State      *CheckpointState
+}
+
+// EventType, CheckpointActivated 类 event.Event.EventType() event.Type 的实现
+func (e *CheckpointActivated) EventType() event.Type { return EventCheckpointActivated }
+
+// AbilityActivated, 玩家角色技能激活事件
+type AbilityActivated struct {
+	Ability *Ability
+}
+
+// EventType, AbilityActivated 类 event.Event.EventType() event.Type 的实现
+func (e *AbilityActivated) EventType() event.Type { return EventAbilityActivated }
+
+// emit, Scene 类将事件加入事件总线队列的包内方法，未设置事件总线时忽略
+// 参数:
+//     e: event.Event 接口对象
+func (s *Scene) emit(e event.Event) {
+	if s.Events != nil {
+		s.Events.Enqueue(e)
+	}
+}
+
+// hurtPlayer, Scene 类使玩家角色受到伤害并发布伤害与死亡事件的包内方法
+// 参数:
+//     d: ecs.Damage 类，伤害事件
+// 返回值:
+//     bool 类型， true 为伤害生效
+func (s *Scene) hurtPlayer(d ecs.Damage) bool {
+	if !s.Player.TakeDamage(d) {
+		return false
+	}
+	s.emit(&PlayerDamaged{Player: s.Player, Damage: d})
+	if s.Player.HasTags("isDead") {
+		s.emit(&PlayerDied{Player: s.Player, Damage: d})
+	}
+	return true
+}
@@ -0,0 +1,288 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+)
+
+// Mana, 魔力对象，供多把武器共享，随时间恢复
+type Mana struct {
+	Value, Max float32
+	// 每秒恢复量
+	Regen float32
+}
+
+// NewMana, Mana 类实例初始化函数
+// 参数:
+//     max: 魔力上限，初始魔力为满
+//     regen: 每秒恢复量
+// 返回值:
+//     Mana 类指针
+func NewMana(max, regen float32) *Mana {
+	return &Mana{Value: max, Max: max, Regen: regen}
+}
+
+// Update, Mana 类恢复魔力的方法
+// 参数:
+//     delta: 与上次更新的时延
+func (m *Mana) Update(delta float64) {
+	m.Value += m.Regen * float32(delta)
+	if m.Value > m.Max {
+		m.Value = m.Max
+	}
+}
+
+// Spend, Mana 类消耗魔力的方法
+// 参数:
+//     cost: 消耗量
+// 返回值:
+//     bool 类型，魔力不足时不消耗并返回 false
+func (m *Mana) Spend(cost float32) bool {
+	if m.Value < cost {
+		return false
+	}
+	m.Value -= cost
+	return true
+}
+
+// Ratio, Mana 类获取当前魔力比例的方法，用于 HUD 显示
+// 返回值:
+//     float32 类型，取值 [0, 1]
+func (m *Mana) Ratio() float32 {
+	if m.Max <= 0 {
+		return 0
+	}
+	return m.Value / m.Max
+}
+
+// WeaponSlot, 武器槽位对象，记录武器及其弹药与魔力消耗
+type WeaponSlot struct {
+	// 武器名称，拾取同名武器时补充弹药
+	Name   string
+	Weapon Weapon
+	// 当前弹药与弹药上限，上限不大于 0 时不消耗弹药
+	Ammo, MaxAmmo int
+	// 每次攻击消耗的魔力，为 0 时不消耗魔力
+	ManaCost float32
+}
+
+// NewWeaponSlot, WeaponSlot 类实例初始化函数
+// 参数:
+//     name: 武器名称
+//     weapon: Weapon 接口对象
+// 返回值:
+//     WeaponSlot 类指针，不消耗弹药与魔力
+func NewWeaponSlot(name string, weapon Weapon) *WeaponSlot {
+	return &WeaponSlot{Name: name, Weapon: weapon}
+}
+
+// CoolDown, WeaponSlot 类获取武器冷却状态的方法，用于 HUD 显示
+// 返回值:
+//     float64 类型，剩余冷却时长
+//     float64 类型，冷却总时长
+func (ws *WeaponSlot) CoolDown() (float64, float64) {
+	return ws.Weapon.CoolDownState()
+}
+
+// Inventory, 武器库对象，管理多个武器槽位与共享魔力，查询与切换方法可在 nil 上调用
+type Inventory struct {
+	Slots []*WeaponSlot
+	// 共享魔力，为 nil 时消耗魔力的武器无法使用
+	Mana *Mana
+	// 切换武器回调
+	OnChange func(slot *WeaponSlot)
+	current  int
+}
+
+// NewInventory, Inventory 类实例初始化函数
+// 参数:
+//     mana: Mana 类指针，可为 nil
+// 返回值:
+//     Inventory 类指针
+func NewInventory(mana *Mana) *Inventory {
+	return &Inventory{Slots: make([]*WeaponSlot, 0), Mana: mana}
+}
+
+// Add, Inventory 类添加武器的方法，已有同名武器时补充其弹药
+// 参数:
+//     slot: WeaponSlot 类指针
+// 返回值:
+//     int 类型，武器所在槽位
+func (inv *Inventory) Add(slot *WeaponSlot) int {
+	for i, s := range inv.Slots {
+		if s.Name == slot.Name {
+			s.Ammo += slot.Ammo
+			if s.MaxAmmo > 0 && s.Ammo > s.MaxAmmo {
+				s.Ammo = s.MaxAmmo
+			}
+			return i
+		}
+	}
+	inv.Slots = append(inv.Slots, slot)
+	if len(inv.Slots) == 1 && inv.OnChange != nil {
+		inv.OnChange(slot)
+	}
+	return len(inv.Slots) - 1
+}
+
+// Select, Inventory 类按槽位选择武器的方法
+// 参数:
+//     index: 槽位
+// 返回值:
+//     bool 类型，槽位不存在时为 false
+func (inv *Inventory) Select(index int) bool {
+	if inv == nil || index < 0 || index >= len(inv.Slots) {
+		return false
+	}
+	changed := index != inv.current
+	inv.current = index
+	if changed && inv.OnChange != nil {
+		inv.OnChange(inv.Slots[index])
+	}
+	return true
+}
+
+// Next, Inventory 类切换至下一把武器的方法
+func (inv *Inventory) Next() {
+	if inv == nil {
+		return
+	}
+	if n := len(inv.Slots); n > 0 {
+		inv.Select((inv.current + 1) % n)
+	}
+}
+
+// Prev, Inventory 类切换至上一把武器的方法
+func (inv *Inventory) Prev() {
+	if inv == nil {
+		return
+	}
+	if n := len(inv.Slots); n > 0 {
+		inv.Select((inv.current + n - 1) % n)
+	}
+}
+
+// Current, Inventory 类获取当前武器槽位的方法
+// 返回值:
+//     WeaponSlot 类指针，武器库为空时为 nil
+func (inv *Inventory) Current() *WeaponSlot {
+	if inv == nil || inv.current >= len(inv.Slots) {
+		return nil
+	}
+	return inv.Slots[inv.current]
+}
+
+// Index, Inventory 类获取当前槽位的方法
+// 返回值:
+//     int 类型，武器库为 nil 时为 0
+func (inv *Inventory) Index() int {
+	if inv == nil {
+		return 0
+	}
+	return inv.current
+}
+
+// CanUse, Inventory 类判断武器弹药与魔力是否足够的方法，武器库为 nil 时视为无魔力
+// 参数:
+//     slot: WeaponSlot 类指针
+// 返回值:
+//     bool 类型，slot 为 nil 时为 false
+func (inv *Inventory) CanUse(slot *WeaponSlot) bool {
+	if slot == nil {
+		return false
+	}
+	if slot.MaxAmmo > 0 && slot.Ammo <= 0 {
+		return false
+	}
+	var mana *Mana
+	if inv != nil {
+		mana = inv.Mana
+	}
+	if slot.ManaCost > 0 && (mana == nil || mana.Value < slot.ManaCost) {
+		return false
+	}
+	return true
+}
+
+// Update, Inventory 类冷却全部武器并恢复魔力的方法，未选中的武器同样冷却
+// 参数:
+//     delta: 与上次更新的时延
+func (inv *Inventory) Update(delta float64) {
+	if inv == nil {
+		return
+	}
+	for _, s := range inv.Slots {
+		s.Weapon.CoolDown(delta)
+	}
+	if inv.Mana != nil {
+		inv.Mana.Update(delta)
+	}
+}
+
+// has, Inventory 类判断武器是否在武器库中的包内方法
+// 参数:
+//     w: Weapon 接口对象
+// 返回值:
+//     bool 类型
+func (inv *Inventory) has(w Weapon) bool {
+	if inv == nil {
+		return false
+	}
+	for _, s := range inv.Slots {
+		if s.Weapon == w {
+			return true
+		}
+	}
+	return false
+}
+
+// consume, Inventory 类扣除武器攻击消耗的包内方法
+// 参数:
+//     slot: WeaponSlot 类指针
+func (inv *Inventory) consume(slot *WeaponSlot) {
+	if slot.MaxAmmo > 0 {
+		slot.Ammo--
+	}
+	if slot.ManaCost > 0 && inv.Mana != nil {
+		inv.Mana.Spend(slot.ManaCost)
+	}
+}
+
+// WeaponPickup, 武器拾取物对象，作为含 "pickup" 标签的形状对象的 Data，玩家角色接触时获得武器或补充弹药
+type WeaponPickup struct {
+	Slot *WeaponSlot
+}
+
+// NewWeaponPickup, 武器拾取物形状对象初始化函数
+// 参数:
+//     x, y: 拾取物坐标
+//     w, h: 拾取物尺寸
+//     slot: WeaponSlot 类指针，获得的武器，其 Ammo 为补充的弹药数
+//     textures: 渲染用 Texture 对象名列表
+// 返回值:
+//     resolv.Rectangle 类指针
+func NewWeaponPickup(x, y, w, h int32, slot *WeaponSlot, textures ...string) *resolv.Rectangle {
+	r := resolv.NewRectangle(x, y, w, h, 0, 1, nil, resource.GetTexturesByName(textures...))
+	r.AddTags("pickup")
+	r.SetData(&WeaponPickup{Slot: slot})
+	return r
+}
+
+// updatePickups, Scene 类处理玩家角色拾取武器的包内方法，拾取后移除拾取物
+func (s *Scene) updatePickups() {
+	if s.Player.Inventory == nil || s.Player.HasTags("isDead") {
+		return
+	}
+	colliding := s.Map.FilterByTags("pickup").GetCollidingShapes(s.Player)
+	for i := 0; i < colliding.Length(); i++ {
+		shape := colliding.Get(i)
+		pickup, ok := shape.GetData().(*WeaponPickup)
+		if !ok || pickup.Slot == nil {
+			continue
+		}
+		index := s.Player.Inventory.Add(pickup.Slot)
+		s.emit(&WeaponPickedUp{Slot: s.Player.Inventory.Slots[index], Index: index})
+		pickup.Slot = nil
+		s.Remove(shape)
+	}
+}
@@ -0,0 +1,136 @@
+package scene
+
+import (
+	"fmt"
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+)
+
+// RenderLayer, 渲染层对象，定义渲染层的序号、名称与可见性
+type RenderLayer struct {
+	ID      int
+	Name    string
+	Visible bool
+}
+
+// RenderLayers, 渲染层列表对象，列表顺序即渲染顺序，靠后的渲染层覆盖靠前的渲染层
+type RenderLayers struct {
+	layers []*RenderLayer
+}
+
+// NewRenderLayers, RenderLayers 类实例初始化函数
+// 返回值:
+//     RenderLayers 类指针，不含任何渲染层
+func NewRenderLayers() *RenderLayers {
+	return &RenderLayers{layers: make([]*RenderLayer, 0)}
+}
+
+// NewDefaultRenderLayers, RenderLayers 类默认实例初始化函数
+// 返回值:
+//     RenderLayers 类指针，依次包含 background、terrain、actors、projectiles、foreground、ui 渲染层
+func NewDefaultRenderLayers() *RenderLayers {
+	l := NewRenderLayers()
+	l.Add(render.LayerBackground, "background")
+	l.Add(render.LayerTerrain, "terrain")
+	l.Add(render.LayerActors, "actors")
+	l.Add(render.LayerProjectiles, "projectiles")
+	l.Add(render.LayerForeground, "foreground")
+	l.Add(render.LayerUI, "ui")
+	return l
+}
+
+// Add, RenderLayers 类在列表末尾（最上层）添加可见渲染层的方法
+// 参数:
+//     id: 渲染层序号，取值 [0, render.MaxLayers)
+//     name: 渲染层名称
+// 返回值:
+//     error 类型，序号超出范围或序号、名称重复时返回错误
+func (l *RenderLayers) Add(id int, name string) error {
+	if id < 0 || id >= render.MaxLayers {
+		return fmt.Errorf("render layer id %d out of range [0, %d)", id, render.MaxLayers)
+	}
+	for _, layer := range l.layers {
+		if layer.ID == id || layer.Name == name {
+			return fmt.Errorf("render layer %d %q already exists", id, name)
+		}
+	}
+	l.layers = append(l.layers, &RenderLayer{ID: id, Name: name, Visible: true})
+	return nil
+}
+
+// Get, RenderLayers 类根据名称获取渲染层的方法
+// 参数:
+//     name: 渲染层名称
+// 返回值:
+//     RenderLayer 类指针，不存在时为 nil
+func (l *RenderLayers) Get(name string) *RenderLayer {
+	for _, layer := range l.layers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// SetVisible, RenderLayers 类设置渲染层可见性的方法
+// 参数:
+//     name: 渲染层名称
+//     visible: 是否可见
+// 返回值:
+//     bool 类型，渲染层不存在时为 false
+func (l *RenderLayers) SetVisible(name string, visible bool) bool {
+	layer := l.Get(name)
+	if layer == nil {
+		return false
+	}
+	layer.Visible = visible
+	return true
+}
+
+// Layers, RenderLayers 类获取全部渲染层的方法
+// 返回值:
+//     RenderLayer 类指针切片，按渲染顺序排列
+func (l *RenderLayers) Layers() []*RenderLayer {
+	layers := make([]*RenderLayer, len(l.layers))
+	copy(layers, l.layers)
+	return layers
+}
+
+// VisibleMask, RenderLayers 类获取全部可见渲染层掩码的方法，未列入列表的渲染层视为可见
+// 返回值:
+//     render.LayerMask 类型
+func (l *RenderLayers) VisibleMask() render.LayerMask {
+	mask := render.AllLayers
+	for _, layer := range l.layers {
+		if !layer.Visible {
+			mask &^= render.LayerMaskOf(layer.ID)
+		}
+	}
+	return mask
+}
+
+// order, RenderLayers 类获取渲染层渲染次序的包内方法
+// 参数:
+//     id: 渲染层序号
+// 返回值:
+//     int 类型，未列入列表的渲染层排在全部渲染层之后
+func (l *RenderLayers) order(id int) int {
+	for i, layer := range l.layers {
+		if layer.ID == id {
+			return i
+		}
+	}
+	return len(l.layers)
+}
+
+// layerOf, 获取形状对象渲染层序号的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     int 类型，未实现 resolv.Layered 接口时为 render.LayerTerrain
+func layerOf(shape resolv.Shape) int {
+	if l, ok := shape.(resolv.Layered); ok {
+		return l.GetLayer()
+	}
+	return render.LayerTerrain
+}
@@ -0,0 +1,239 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// MeleeWeapon, 近战武器类，攻击时在攻击方向上生成跟随攻击者的限时判定框
+type MeleeWeapon struct {
+	// 挥砍效果 Texture 对象名，为空时判定框不渲染
+	SlashName string
+	CD        float64
+	CDDelta   float64
+	// 判定框中心与攻击初始坐标的距离
+	Reach int32
+	// 判定框尺寸
+	W, H int32
+	// 判定框持续时长，单位为秒
+	Duration float64
+	// 伤害数值与击退力度
+	Damage    int
+	Knockback float32
+	// 攻击时的镜头震动强度增量，为 0 时不震动
+	Trauma float32
+}
+
+// NewSword, 剑武器实例化函数
+// 返回值:
+//     MeleeWeapon 类指针
+func NewSword() *MeleeWeapon {
+	return &MeleeWeapon{
+		CD:        0.4,
+		Reach:     40,
+		W:         60,
+		H:         50,
+		Duration:  0.15,
+		Damage:    2,
+		Knockback: 10,
+		Trauma:    0.1,
+	}
+}
+
+// Attack, MeleeWeapon 类攻击方法， Weapon.Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *AttackResult 的实现
+// 参数:
+//     X, Y: 攻击初始坐标
+//     vec2: 攻击矢量
+//     isXReverse: 图像是水平镜像向后的
+// 返回值:
+//     AttackResult 类指针，含近战判定框，冷却中时为 nil
+func (mw *MeleeWeapon) Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *AttackResult {
+	if mw.CDDelta > 0 {
+		return nil
+	}
+	mw.CDDelta = mw.CD
+
+	if vec2.Len() == 0 {
+		vec2 = mgl32.Vec2{1, 0}
+		if isXReverse {
+			vec2[0] = -1
+		}
+	}
+	offset := vec2.Normalize().Mul(float32(mw.Reach))
+	cx := X + int32(offset[0])
+	cy := Y + int32(offset[1])
+
+	var textures []*resource.Texture2D
+	if mw.SlashName != "" {
+		textures = resource.GetTexturesByName(mw.SlashName)
+	}
+	box := NewHitbox(cx-mw.W/2, cy-mw.H/2, mw.W, mw.H, mw.Duration, textures)
+	box.IsXReverse = isXReverse
+	box.SetData(&Hazard{Damage: mw.Damage, Knockback: mw.Knockback})
+
+	result := &AttackResult{Shapes: []resolv.Shape{box}}
+	if mw.Trauma > 0 {
+		result.Effects = append(result.Effects, ShakeEffect(mw.Trauma))
+	}
+	return result
+}
+
+// CoolDown, MeleeWeapon 类攻击冷却方法， Weapon.CoolDown(delta float64) 的实现
+// 参数:
+//     delta: 上次更新后时延
+func (mw *MeleeWeapon) CoolDown(delta float64) {
+	mw.CDDelta -= delta
+}
+
+// CoolDownState, MeleeWeapon 类获取冷却状态的方法， Weapon.CoolDownState() (float64, float64) 的实现
+// 返回值:
+//     float64 类型，剩余冷却时长，不小于 0
+//     float64 类型，冷却总时长
+func (mw *MeleeWeapon) CoolDownState() (float64, float64) {
+	return coolDownLeft(mw.CDDelta), mw.CD
+}
+
+// Hitbox, 近战判定框对象，在持续时长内跟随攻击者移动，同一次攻击对每个目标只造成一次伤害
+type Hitbox struct {
+	resolv.Rectangle
+	// 持续时长，单位为秒
+	Duration float64
+	elapsed  float64
+	// 跟随的攻击者及判定框相对攻击者的偏移
+	anchor           resolv.Shape
+	offsetX, offsetY int32
+	hits             map[interface{}]bool
+}
+
+// NewHitbox, Hitbox 类实例初始化函数
+// 参数:
+//     x, y: 判定框坐标
+//     w, h: 判定框尺寸
+//     duration: 持续时长
+//     textures: 渲染用 Texture 对象列表，为空时判定框不渲染
+// 返回值:
+//     Hitbox 类指针
+func NewHitbox(x, y, w, h int32, duration float64, textures []*resource.Texture2D) *Hitbox {
+	r := resolv.NewRectangle(x, y, w, h, 0, 1, nil, textures)
+	b := &Hitbox{
+		Rectangle: *r,
+		Duration:  duration,
+		hits:      make(map[interface{}]bool),
+	}
+	b.SetLayer(render.LayerProjectiles)
+	if len(textures) == 0 {
+		b.AddTags("hide")
+	}
+	return b
+}
+
+// Follow, Hitbox 类设置跟随攻击者的方法，判定框将保持与攻击者的当前相对位置
+// 参数:
+//     anchor: resolv.Shape 接口对象，攻击者
+func (b *Hitbox) Follow(anchor resolv.Shape) {
+	b.anchor = anchor
+	ax, ay := anchor.GetXY()
+	b.offsetX = b.X - ax
+	b.offsetY = b.Y - ay
+}
+
+// Hit, Hitbox 类登记命中目标的方法
+// 参数:
+//     target: 命中目标
+// 返回值:
+//     bool 类型，本次攻击首次命中该目标时为 true
+func (b *Hitbox) Hit(target interface{}) bool {
+	if b.hits[target] {
+		return false
+	}
+	b.hits[target] = true
+	return true
+}
+
+// Expired, Hitbox 类判断判定框是否已失效的方法
+// 返回值:
+//     bool 类型
+func (b *Hitbox) Expired() bool {
+	return b.elapsed >= b.Duration
+}
+
+// update, Hitbox 类推进持续时间并跟随攻击者的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (b *Hitbox) update(delta float64) {
+	b.elapsed += delta
+	if b.anchor != nil {
+		ax, ay := b.anchor.GetXY()
+		b.SetXY(ax+b.offsetX, ay+b.offsetY)
+	}
+}
+
+// follower, 跟随攻击者的攻击作用形状接口对象，如近战判定框
+type follower interface {
+	Follow(anchor resolv.Shape)
+}
+
+// hitTracker, 限制同一目标命中次数的攻击作用形状接口对象
+type hitTracker interface {
+	Hit(target interface{}) bool
+}
+
+// registerHit, 登记攻击作用形状对象命中目标的包内函数
+// 参数:
+//     source: 攻击作用形状对象
+//     target: 命中目标
+// 返回值:
+//     bool 类型，伤害应生效时为 true，攻击作用形状对象未实现 hitTracker 接口时始终为 true
+func registerHit(source resolv.Shape, target interface{}) bool {
+	if t, ok := source.(hitTracker); ok {
+		return t.Hit(target)
+	}
+	return true
+}
+
+// applyAttack, Scene 类处理攻击结果的包内方法，攻击作用形状对象以攻击者作为伤害发起者加入场景空间，随后执行附带效果
+// 参数:
+//     attacker: resolv.Shape 接口对象，攻击者
+//     result: AttackResult 类指针，可为 nil
+// 返回值:
+//     bool 类型，攻击生效时为 true
+func (s *Scene) applyAttack(attacker resolv.Shape, result *AttackResult) bool {
+	if result == nil {
+		return false
+	}
+	for _, shape := range result.Shapes {
+		setHazardOwner(shape, attacker)
+		if f, ok := shape.(follower); ok {
+			f.Follow(attacker)
+		}
+		s.Map.Add(shape)
+	}
+	for _, effect := range result.Effects {
+		effect(s)
+	}
+	return true
+}
+
+// updateHitboxes, Scene 类更新场景内近战判定框的包内方法，判定框跟随攻击者并对接触的实体造成伤害，失效后移除
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateHitboxes(delta float64) {
+	// 复用临时列表，避免每次更新分配内存
+	boxes := s.hitboxes[:0]
+	for _, shape := range *s.Map {
+		if b, ok := shape.(*Hitbox); ok {
+			boxes = append(boxes, b)
+		}
+	}
+	for _, b := range boxes {
+		b.update(delta)
+		if b.Expired() {
+			s.Remove(b)
+			continue
+		}
+		s.damageEntities(b, nil)
+	}
+	s.hitboxes = boxes[:0]
+}
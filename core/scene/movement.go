@@ -0,0 +1,375 @@
+package scene
+
+import (
+	"encoding/json"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"io/ioutil"
+)
+
+// MovementProfile, 玩家角色移动参数对象，定义加减速、重力与跳跃手感，速度单位为每次更新的像素数，时长单位为秒，
+// 可由 JSON 格式的配置文件加载
+// 示例:
+//     {"ground_accel": 1, "air_accel": 0.3, "gravity": 0.5, "jump_speed": 16, "air_jumps": 1}
+type MovementProfile struct {
+	// 地面上的加速度与减速度，地面阻力小于减速度（如冰面）时加减速同步减小
+	GroundAccel float32 `json:"ground_accel"`
+	GroundDecel float32 `json:"ground_decel"`
+	// 空中的加速度与减速度，加速度为 0 时空中无法转向
+	AirAccel float32 `json:"air_accel"`
+	AirDecel float32 `json:"air_decel"`
+	// 每次更新增加的下落速度
+	Gravity float32 `json:"gravity"`
+	// 最大下落速度，不大于 0 时不限
+	MaxFallSpeed float32 `json:"max_fall_speed"`
+	// 下落时按住下移键的重力倍率
+	FastFallGravity float32 `json:"fast_fall_gravity"`
+	// 按住跳跃键经过跳跃顶点时的重力倍率，用于延长滞空
+	ApexGravity float32 `json:"apex_gravity"`
+	// 视为处于跳跃顶点的垂直速度阈值
+	ApexThreshold float32 `json:"apex_threshold"`
+	// 向下检测着陆点的距离，同时用于下坡时吸附斜坡
+	GroundProbe int32 `json:"ground_probe"`
+	// 起跳速度
+	JumpSpeed float32 `json:"jump_speed"`
+	// 提前松开跳跃键时上升速度的保留比例，取值 (0, 1]，为 1 时跳跃高度不可变
+	JumpCut float32 `json:"jump_cut"`
+	// 离开平台后仍可起跳的时长（土狼时间）
+	CoyoteTime float64 `json:"coyote_time"`
+	// 落地前按下跳跃键后保留跳跃输入的时长
+	JumpBuffer float64 `json:"jump_buffer"`
+	// 空中可额外跳跃的次数
+	AirJumps int `json:"air_jumps"`
+	// 空中跳跃的起跳速度
+	AirJumpSpeed float32 `json:"air_jump_speed"`
+	// 是否允许贴墙滑落与蹬墙跳
+	WallJump bool `json:"wall_jump"`
+	// 贴墙滑落的最大下落速度
+	WallSlideSpeed float32 `json:"wall_slide_speed"`
+	// 蹬墙跳的水平与垂直起跳速度
+	WallJumpSpeedX float32 `json:"wall_jump_speed_x"`
+	WallJumpSpeedY float32 `json:"wall_jump_speed_y"`
+	// 攀爬梯子与藤蔓的速度
+	ClimbSpeed float32 `json:"climb_speed"`
+	// 水中每次更新增加的下落速度，即重力减去浮力，为负数时上浮
+	SwimGravity float32 `json:"swim_gravity"`
+	// 水中每次更新保留的速度比例，取值 [0, 1]
+	SwimDrag float32 `json:"swim_drag"`
+	// 水中的加速度与最大水平速度
+	SwimAccel    float32 `json:"swim_accel"`
+	SwimMaxSpeed float32 `json:"swim_max_speed"`
+	// 划水时的上升速度，头部露出水面时以起跳速度跃出水面
+	SwimStroke float32 `json:"swim_stroke"`
+	// 头部没入水中后的憋气时长，不大于 0 时不限
+	BreathTime float64 `json:"breath_time"`
+	// 憋气耗尽后每隔 DrownInterval 受到的溺水伤害
+	DrownDamage   int     `json:"drown_damage"`
+	DrownInterval float64 `json:"drown_interval"`
+}
+
+// NewDefaultMovementProfile, MovementProfile 类默认实例初始化函数，地面移动、起跳与重力与原有手感一致，
+// 空中可小幅转向，不允许空中跳跃与蹬墙跳
+// 返回值:
+//     MovementProfile 类指针
+func NewDefaultMovementProfile() *MovementProfile {
+	return &MovementProfile{
+		GroundAccel:     1,
+		GroundDecel:     0.5,
+		AirAccel:        0.2,
+		AirDecel:        0.01,
+		Gravity:         0.5,
+		MaxFallSpeed:    0,
+		FastFallGravity: 2,
+		ApexGravity:     1,
+		ApexThreshold:   2,
+		GroundProbe:     4,
+		JumpSpeed:       16,
+		JumpCut:         0.5,
+		CoyoteTime:      0.1,
+		JumpBuffer:      0.1,
+		AirJumps:        0,
+		AirJumpSpeed:    14,
+		WallJump:        false,
+		WallSlideSpeed:  3,
+		WallJumpSpeedX:  6,
+		WallJumpSpeedY:  14,
+		ClimbSpeed:      4,
+		SwimGravity:     0.1,
+		SwimDrag:        0.9,
+		SwimAccel:       0.4,
+		SwimMaxSpeed:    3,
+		SwimStroke:      6,
+		BreathTime:      8,
+		DrownDamage:     1,
+		DrownInterval:   1,
+	}
+}
+
+// LoadMovementProfile, 从 JSON 格式的配置文件加载移动参数的函数，配置中未出现的参数取默认值
+// 参数:
+//     file: 配置文件路径
+// 返回值:
+//     MovementProfile 类指针
+//     error 类型，读取或解析失败时返回错误
+func LoadMovementProfile(file string) (*MovementProfile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMovementProfile(data)
+}
+
+// ParseMovementProfile, 解析 JSON 格式移动参数的函数，配置中未出现的参数取默认值
+// 参数:
+//     data: JSON 数据
+// 返回值:
+//     MovementProfile 类指针
+//     error 类型，解析失败时返回错误
+func ParseMovementProfile(data []byte) (*MovementProfile, error) {
+	m := NewDefaultMovementProfile()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Save, MovementProfile 类将移动参数保存至配置文件的方法
+// 参数:
+//     file: 配置文件路径
+// 返回值:
+//     error 类型，写入失败时返回错误
+func (m *MovementProfile) Save(file string) error {
+	data, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// defaultMovementProfile, 未设置 Player.Movement 时使用的移动参数
+var defaultMovementProfile = NewDefaultMovementProfile()
+
+// jumpState, 玩家角色跳跃的运行状态
+type jumpState struct {
+	// 剩余土狼时间与跳跃输入保留时长
+	coyote, buffer float64
+	// 本次离地后已进行的空中跳跃次数
+	airJumps int
+	// 是否处于可被提前松键截断的上升过程
+	jumping bool
+	// 贴靠的墙壁方向，-1 为左，1 为右，0 为未贴墙
+	wallDir int
+	// 是否贴墙滑落
+	wallSliding bool
+}
+
+// movement, Player 类获取移动参数的包内方法
+// 返回值:
+//     MovementProfile 类指针，未设置 Player.Movement 时为默认参数
+func (p *Player) movement() *MovementProfile {
+	if p.Movement == nil {
+		return defaultMovementProfile
+	}
+	return p.Movement
+}
+
+// WallSliding, Player 类判断角色是否贴墙滑落的方法
+// 返回值:
+//     bool 类型
+func (p *Player) WallSliding() bool {
+	return p.jump.wallSliding
+}
+
+// AirJumpsLeft, Player 类获取剩余空中跳跃次数的方法
+// 返回值:
+//     int 类型
+func (p *Player) AirJumpsLeft() int {
+	return p.movement().AirJumps - p.jump.airJumps
+}
+
+// playerGravity, Scene 类对玩家角色施加重力的包内方法，下落时按住下移键加速下落，按住跳跃键经过跳跃顶点时减缓下落，
+// 攀爬时无重力，游泳时受浮力抵消
+func (s *Scene) playerGravity() {
+	p := s.Player
+	m := p.movement()
+	switch p.region.mode {
+	case MoveClimb:
+		return
+	case MoveSwim:
+		p.SpeedY += m.SwimGravity
+		return
+	}
+	gravity := m.Gravity
+	if !p.onGround && !p.HasTags("isDead") {
+		switch {
+		case p.SpeedY > 0 && s.Input.Pressed(input.ActionMoveDown):
+			gravity *= m.FastFallGravity
+		case p.SpeedY > -m.ApexThreshold && p.SpeedY < m.ApexThreshold && s.Input.Pressed(input.ActionJump):
+			gravity *= m.ApexGravity
+		}
+	}
+	p.SpeedY += gravity
+}
+
+// playerMove, Scene 类玩家角色移动的包内方法，按移动参数在地面与空中加减速，攀爬与游泳时按相应方式移动
+// 参数:
+//     down: resolv.Collision 类，角色着陆点所在对象
+func (s *Scene) playerMove(down resolv.Collision) {
+	p := s.Player
+	switch p.region.mode {
+	case MoveClimb:
+		s.climbMove()
+		return
+	case MoveSwim:
+		s.swimMove()
+		return
+	}
+	m := p.movement()
+	accel, decel := m.AirAccel, m.AirDecel
+	if down.Colliding() {
+		accel, decel = m.GroundAccel, m.GroundDecel
+		// 地面阻力较小时加减速同步减小
+		if friction := down.ShapeB.GetFriction(); friction < decel {
+			accel -= decel - friction
+			decel = friction
+		}
+	}
+
+	if p.SpeedX > decel {
+		p.SpeedX -= decel
+	} else if p.SpeedX < -decel {
+		p.SpeedX += decel
+	} else {
+		p.SpeedX = 0
+	}
+
+	if s.Input.Pressed(input.ActionMoveLeft) || s.Input.Pressed(input.ActionMoveRight) {
+		p.IsMove = true
+	}
+
+	if !p.HasTags("isDead") && accel > 0 {
+		if s.Input.Pressed(input.ActionMoveRight) {
+			p.IsXReverse = false
+			p.SpeedX += accel
+		}
+		if s.Input.Pressed(input.ActionMoveLeft) {
+			p.IsXReverse = true
+			p.SpeedX -= accel
+		}
+	}
+
+	if p.SpeedX > p.GetMaxSpd() {
+		p.SpeedX = p.GetMaxSpd()
+	}
+	if p.SpeedX < -p.GetMaxSpd() {
+		p.SpeedX = -p.GetMaxSpd()
+	}
+}
+
+// playerBlocked, Scene 类判断玩家角色在指定方向上是否紧贴 "solid" 形状对象的包内方法
+// 参数:
+//     dx, dy: 检测方向上的位移
+// 返回值:
+//     bool 类型，位移为 0 时为 false
+func (s *Scene) playerBlocked(dx, dy int32) bool {
+	if dx == 0 && dy == 0 {
+		return false
+	}
+	solids := s.Map.Filter(func(shape resolv.Shape) bool {
+		return shape.HasTags("solid") && !shape.HasTags("destroyed")
+	})
+	res := solids.Resolve(s.Player, dx, dy)
+	return res.Colliding()
+}
+
+// playerWall, Scene 类获取玩家角色贴靠的墙壁方向的包内方法
+// 返回值:
+//     int 类型，-1 为左，1 为右，0 为未贴墙
+func (s *Scene) playerWall() int {
+	if s.playerBlocked(-1, 0) {
+		return -1
+	}
+	if s.playerBlocked(1, 0) {
+		return 1
+	}
+	return 0
+}
+
+// playerJump, Scene 类玩家角色跳跃的包内方法，处理土狼时间、跳跃输入缓冲、可变跳跃高度、空中跳跃与贴墙滑落及蹬墙跳，
+// 攀爬时跳离，游泳时划水
+// 参数:
+//     onGround: bool 类，角色是否着陆
+//     delta: 与上次更新的时延
+func (s *Scene) playerJump(onGround bool, delta float64) {
+	p := s.Player
+	switch p.region.mode {
+	case MoveClimb:
+		s.climbJump()
+		return
+	case MoveSwim:
+		s.swimStroke()
+		return
+	}
+	m := p.movement()
+	st := &p.jump
+	dead := p.HasTags("isDead")
+
+	if onGround {
+		st.coyote = m.CoyoteTime
+		st.airJumps = 0
+	} else {
+		st.coyote -= delta
+	}
+	st.buffer -= delta
+	requested := s.Input.JustPressed(input.ActionJump) && !dead
+	if requested {
+		st.buffer = m.JumpBuffer
+	}
+
+	// 贴墙且朝墙壁方向移动时滑落
+	st.wallDir = 0
+	if m.WallJump && !onGround && !dead {
+		st.wallDir = s.playerWall()
+	}
+	pushing := (st.wallDir < 0 && s.Input.Pressed(input.ActionMoveLeft)) ||
+		(st.wallDir > 0 && s.Input.Pressed(input.ActionMoveRight))
+	st.wallSliding = pushing && p.SpeedY > 0
+	if st.wallSliding && p.SpeedY > m.WallSlideSpeed {
+		p.SpeedY = m.WallSlideSpeed
+	}
+	if m.MaxFallSpeed > 0 && p.SpeedY > m.MaxFallSpeed {
+		p.SpeedY = m.MaxFallSpeed
+	}
+
+	if requested || (st.buffer > 0 && !dead) {
+		speed := float32(0)
+		switch {
+		case onGround || st.coyote > 0:
+			speed = m.JumpSpeed
+		case st.wallDir != 0:
+			speed = m.WallJumpSpeedY
+			p.SpeedX = -float32(st.wallDir) * m.WallJumpSpeedX
+			p.IsXReverse = st.wallDir > 0
+			st.wallSliding = false
+		case requested && st.airJumps < m.AirJumps:
+			// 空中跳跃仅响应本次按键，不消耗缓冲的跳跃输入
+			speed = m.AirJumpSpeed
+			st.airJumps++
+		}
+		if speed > 0 {
+			p.IsMove = true
+			p.SpeedY = -speed
+			st.coyote, st.buffer = 0, 0
+			st.jumping = true
+		}
+	}
+
+	// 上升过程中松开跳跃键时截断上升速度
+	if st.jumping && p.SpeedY < 0 && !s.Input.Pressed(input.ActionJump) {
+		p.SpeedY *= m.JumpCut
+		st.jumping = false
+	}
+	if p.SpeedY >= 0 {
+		st.jumping = false
+	}
+}
@@ -0,0 +1,132 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/pool"
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Particle, 粒子对象，以方形作为形状对象，不参与碰撞阻挡，存在时长结束后移除
+type Particle struct {
+	resolv.Rectangle
+	// 存在时长，单位为秒
+	Lifetime float64
+	// 重力系数
+	GravityScale float32
+	age          float64
+	pool         *pool.Pool
+}
+
+// NewParticle, Particle 类实例初始化函数
+// 参数:
+//     size: 粒子边长
+//     textures: 渲染用 Texture 对象列表
+// 返回值:
+//     Particle 类指针
+func NewParticle(size int32, textures []*resource.Texture2D) *Particle {
+	r := resolv.NewRectangle(0, 0, size, size, 0, 1, nil, textures)
+	p := &Particle{Rectangle: *r}
+	p.SetLayer(render.LayerForeground)
+	return p
+}
+
+// release, Particle 类将粒子归还所属对象池的包内方法，重复调用无效
+func (p *Particle) release() {
+	if p.pool == nil {
+		return
+	}
+	pl := p.pool
+	p.pool = nil
+	pl.Release(p)
+}
+
+// ParticleEmitter, 粒子发射器对象，按固定角度间隔发射一组粒子，粒子由对象池复用
+type ParticleEmitter struct {
+	Pool *pool.Pool
+	// 每次发射的粒子数量
+	Count int
+	// 粒子初速度
+	Speed float32
+	// 发射方向两侧的总散布角度，单位为弧度
+	Spread float32
+	// 粒子存在时长，单位为秒
+	Lifetime float64
+	// 粒子重力系数
+	GravityScale float32
+}
+
+// NewParticleEmitter, ParticleEmitter 类实例初始化函数
+// 参数:
+//     textureName: 粒子 Texture 对象名
+//     size: 粒子边长
+//     capacity: 空闲粒子容量上限
+// 返回值:
+//     ParticleEmitter 类指针
+func NewParticleEmitter(textureName string, size int32, capacity int) *ParticleEmitter {
+	return &ParticleEmitter{
+		Pool: pool.NewPool(
+			func() interface{} { return NewParticle(size, resource.GetTexturesByName(textureName)) },
+			func(obj interface{}) {
+				p := obj.(*Particle)
+				p.age = 0
+				p.SetSpd(0, 0)
+			},
+			capacity),
+		Count:        6,
+		Speed:        4,
+		Spread:       math.Pi,
+		Lifetime:     0.3,
+		GravityScale: 0.5,
+	}
+}
+
+// Emit, ParticleEmitter 类发射粒子的方法
+// 参数:
+//     s: Scene 类指针
+//     x, y: 发射中心坐标
+//     dir: 发射方向，零矢量时朝上
+func (pe *ParticleEmitter) Emit(s *Scene, x, y int32, dir mgl32.Vec2) {
+	if dir.Len() == 0 {
+		dir = mgl32.Vec2{0, -1}
+	}
+	dir = dir.Normalize()
+	for i := 0; i < pe.Count; i++ {
+		angle := float32(0)
+		if pe.Count > 1 {
+			angle = pe.Spread * (float32(i)/float32(pe.Count-1) - 0.5)
+		}
+		v := mgl32.Rotate2D(angle).Mul2x1(dir).Mul(pe.Speed)
+
+		p := pe.Pool.Acquire().(*Particle)
+		p.pool = pe.Pool
+		p.Lifetime = pe.Lifetime
+		p.GravityScale = pe.GravityScale
+		p.SetXY(x-p.W/2, y-p.H/2)
+		p.StorePrevXY()
+		p.SetSpd(v[0], v[1])
+		s.Map.Add(p)
+	}
+}
+
+// updateParticles, Scene 类推进场景内粒子的包内方法，存在时长结束的粒子移除并归还对象池
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateParticles(delta float64) {
+	for _, shape := range *s.Map {
+		p, ok := shape.(*Particle)
+		if !ok {
+			continue
+		}
+		p.age += delta
+		if p.age >= p.Lifetime {
+			s.Remove(p)
+			continue
+		}
+		p.SpeedY += 0.5 * p.GravityScale
+		p.X += int32(p.SpeedX)
+		p.Y += int32(p.SpeedY)
+	}
+}
@@ -1,16 +1,40 @@
 package scene
 
 import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/fsm"
+	"github.com/ClessLi/2d-game-engin/core/render"
 	"github.com/ClessLi/2d-game-engin/core/resolv"
 	"github.com/ClessLi/2d-game-engin/resource"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
-// Player, 玩家角色对象，暂以方形作为角色的形状对象，包含了武器类型与攻击矢量
+// Player, 玩家角色对象，暂以方形作为角色的形状对象，包含了武器类型、攻击矢量与角色状态机
 type Player struct {
 	resolv.Rectangle
+	// 武器库为空时使用的武器
 	Weapon Weapon
-	AtkVec mgl32.Vec2
+	// 武器库，非空时使用当前选中的武器
+	Inventory *Inventory
+	// 移动参数，为 nil 时使用默认参数
+	Movement *MovementProfile
+	// 技能列表
+	Abilities []*Ability
+	AtkVec    mgl32.Vec2
+	// 角色生命值
+	Health *ecs.Health
+	// 角色状态机，状态名称见 PlayerIdle 等常量
+	FSM *fsm.Machine
+	// 各状态绑定的动画片段，状态未绑定片段时保持当前纹理
+	Clips    map[string]*AnimationClip
+	animator Animator
+	// 本次更新中的角色状况，供状态转换判定
+	onGround bool
+	attacked bool
+	hurt     bool
+	jump     jumpState
+	region   regionState
+	ability  *Ability
 }
 
 // NewPlayer, Player 类实例初始化函数
@@ -20,7 +44,7 @@ type Player struct {
 //     friction: 角色阻力值
 //     drawMulti: 角色渲染缩放系数
 //     moveList: 动态 Texture 对象名列表
-//     standList: 静态 Texture 对象名列表
+//     standList: 静态 Texture 对象名列表，各状态的默认动画片段由两组纹理以不同帧时长与播放方式生成，可由 SetClip 替换
 // 返回值:
 //     Player 类指针
 func NewPlayer(x, y, w, h int32, friction, drawMulti float32, moveList, standList []string) *Player {
@@ -31,14 +55,169 @@ func NewPlayer(x, y, w, h int32, friction, drawMulti float32, moveList, standLis
 	p := &Player{
 		Rectangle: *r,
 		Weapon:    nil,
+		Inventory: NewInventory(nil),
+		Movement:  NewDefaultMovementProfile(),
+		Clips:     newPlayerClips(resource.GetTexturesByName(moveList...), resource.GetTexturesByName(standList...)),
 	}
+	p.Health = ecs.NewHealth(3)
+	p.Health.RemoveOnDeath = false
+	p.Health.IFrames = 1
+	p.SetLayer(render.LayerActors)
+	p.FSM = newPlayerFSM(p)
 	return p
 }
 
-// Attack, Player 类攻击方法
+// TakeDamage, Player 类受到伤害的方法，伤害生效时角色被击退并进入受击状态，生命值归零时死亡
+// 参数:
+//     d: ecs.Damage 类，伤害事件
+// 返回值:
+//     bool 类型， true 为伤害生效
+func (p *Player) TakeDamage(d ecs.Damage) bool {
+	if !p.Health.TakeDamage(d) {
+		return false
+	}
+	p.SpeedX += d.KnockbackX
+	p.SpeedY += d.KnockbackY
+	if p.Health.IsDead() {
+		p.AddTags("isDead")
+	} else {
+		p.Hurt()
+	}
+	return true
+}
+
+// Revive, Player 类复活角色的方法
+// 参数:
+//     hp: 复活后的生命值，不大于 0 时恢复至最大生命值
+func (p *Player) Revive(hp int) {
+	p.RemoveTags("isDead")
+	p.Health.Revive(hp)
+	p.SpeedX, p.SpeedY = 0, 0
+	p.jump = jumpState{}
+	p.region = regionState{}
+	if p.ability != nil {
+		p.ability.active = false
+		p.ability = nil
+	}
+	p.FSM.Set(PlayerIdle)
+}
+
+// Visible, Player 类判断角色当前是否应渲染的方法，无敌闪烁时间隔隐藏
+// 返回值:
+//     bool 类型
+func (p *Player) Visible() bool {
+	return p.Health == nil || p.Health.Visible()
+}
+
+// State, Player 类获取角色当前状态名称的方法
+// 返回值:
+//     string 类型
+func (p *Player) State() string {
+	return p.FSM.Current()
+}
+
+// SetClip, Player 类为状态绑定动画片段的方法，绑定当前状态时立即播放
+// 参数:
+//     state: 状态名称
+//     clip: AnimationClip 类指针
+func (p *Player) SetClip(state string, clip *AnimationClip) {
+	p.Clips[state] = clip
+	if p.FSM.Is(state) {
+		p.animator.Play(clip)
+	}
+}
+
+// newPlayerClips, 生成玩家角色各状态默认动画片段的包内函数，每个状态绑定独立的动画片段，
+// 跳跃、着陆、攻击与死亡片段播放一遍后停留在最后一帧
+// 参数:
+//     move: 动态纹理帧列表
+//     stand: 静态纹理帧列表
+// 返回值:
+//     map[string]*AnimationClip 类型，以状态名称为键
+func newPlayerClips(move, stand []*resource.Texture2D) map[string]*AnimationClip {
+	clip := func(frames []*resource.Texture2D, duration float32, loop bool) *AnimationClip {
+		c := &AnimationClip{Frames: frames, Loop: loop}
+		if len(frames) > 0 {
+			c.FrameTime = duration / float32(len(frames))
+		}
+		return c
+	}
+	// 死亡片段停留在静态纹理的最后一帧
+	dead := stand
+	if len(stand) > 0 {
+		dead = stand[len(stand)-1:]
+	}
+	return map[string]*AnimationClip{
+		PlayerIdle:      clip(stand, 0.8, true),
+		PlayerRun:       clip(move, 0.4, true),
+		PlayerJump:      clip(move, 0.3, false),
+		PlayerFall:      clip(stand, 0.4, true),
+		PlayerWallSlide: clip(stand, 1.6, true),
+		PlayerClimb:     clip(move, 0.8, true),
+		PlayerSwim:      clip(move, 1.2, true),
+		PlayerLand:      clip(stand, playerLandTime, false),
+		PlayerAttack:    clip(move, playerAttackTime, false),
+		PlayerHurt:      clip(stand, playerHurtTime/2, true),
+		PlayerDead:      clip(dead, 0, false),
+	}
+}
+
+// Hurt, Player 类使角色在下一次状态更新时进入受击状态的方法
+func (p *Player) Hurt() {
+	p.hurt = true
+}
+
+// updateState, Player 类更新角色状态机与动画的包内方法
+// 参数:
+//     onGround: 角色是否着陆
+//     delta: 与上次更新的时延
+func (p *Player) updateState(onGround bool, delta float64) {
+	p.onGround = onGround
+	if p.Health != nil {
+		p.Health.Tick(delta)
+	}
+	p.FSM.Update(delta)
+	p.attacked = false
+	p.hurt = false
+
+	p.animator.Update(float32(delta))
+	if texture := p.animator.Texture(); texture != nil {
+		p.Texture = texture
+	}
+}
+
+// CurrentWeapon, Player 类获取当前武器的方法
 // 返回值:
-//     resolve.Shape 类，武器攻击作用形状对象
-func (p *Player) Attack() resolv.Shape {
+//     Weapon 接口对象，武器库非空时为当前选中的武器，否则为 Player.Weapon
+func (p *Player) CurrentWeapon() Weapon {
+	if slot := p.Inventory.Current(); slot != nil {
+		return slot.Weapon
+	}
+	return p.Weapon
+}
+
+// updateWeapons, Player 类冷却武器并恢复魔力的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (p *Player) updateWeapons(delta float64) {
+	p.Inventory.Update(delta)
+	if p.Weapon != nil && !p.Inventory.has(p.Weapon) {
+		p.Weapon.CoolDown(delta)
+	}
+}
+
+// Attack, Player 类攻击方法，使用武器库中的武器时扣除弹药与魔力
+// 返回值:
+//     AttackResult 类指针，武器攻击结果，无武器、冷却中或弹药与魔力不足时为 nil
+func (p *Player) Attack() *AttackResult {
+	slot := p.Inventory.Current()
+	if slot != nil && !p.Inventory.CanUse(slot) {
+		return nil
+	}
+	weapon := p.CurrentWeapon()
+	if weapon == nil {
+		return nil
+	}
 	x, y := p.GetXY()
 	if !p.IsXReverse {
 		x += p.W * 2 / 3
@@ -47,9 +226,9 @@ func (p *Player) Attack() resolv.Shape {
 	}
 	y += p.H / 4
 
-	switch p.Weapon.(type) {
-	case *LongRangeWeapon:
-		return p.Weapon.Attack(x, y, p.AtkVec, p.IsXReverse)
+	result := weapon.Attack(x, y, p.AtkVec, p.IsXReverse)
+	if result != nil && slot != nil {
+		p.Inventory.consume(slot)
 	}
-	return nil
+	return result
 }
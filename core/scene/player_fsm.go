@@ -0,0 +1,108 @@
+package scene
+
+import "github.com/ClessLi/2d-game-engin/core/fsm"
+
+// 玩家角色状态名称
+const (
+	PlayerIdle      = "idle"
+	PlayerRun       = "run"
+	PlayerJump      = "jump"
+	PlayerFall      = "fall"
+	PlayerWallSlide = "wall_slide"
+	PlayerClimb     = "climb"
+	PlayerSwim      = "swim"
+	PlayerLand      = "land"
+	PlayerAttack    = "attack"
+	PlayerHurt      = "hurt"
+	PlayerDead      = "dead"
+)
+
+// 玩家角色状态时长
+const (
+	playerLandTime   = 0.1
+	playerAttackTime = 0.25
+	playerHurtTime   = 0.4
+)
+
+// playerStates, 玩家角色全部状态名称
+var playerStates = []string{PlayerIdle, PlayerRun, PlayerJump, PlayerFall, PlayerWallSlide, PlayerClimb, PlayerSwim, PlayerLand, PlayerAttack, PlayerHurt, PlayerDead}
+
+// newPlayerFSM, 创建玩家角色状态机的包内函数，各状态进入时播放其绑定的动画片段，重新进入当前状态时从头播放
+// 参数:
+//     p: Player 类指针
+// 返回值:
+//     fsm.Machine 类指针
+func newPlayerFSM(p *Player) *fsm.Machine {
+	m := fsm.NewMachine()
+	for _, name := range playerStates {
+		name := name
+		m.AddState(&fsm.State{
+			Name: name,
+			OnEnter: func(from string) {
+				p.animator.Play(p.Clips[name])
+				if from == name {
+					p.animator.Restart()
+				}
+			},
+		})
+	}
+
+	airborne := func() bool { return !p.onGround }
+	rising := func() bool { return !p.onGround && p.SpeedY < 0 }
+	falling := func() bool { return !p.onGround && p.SpeedY >= 0 }
+	moving := func() bool { return p.IsMove && p.onGround }
+	stopped := func() bool { return !p.IsMove && p.onGround }
+
+	// 死亡与受击可从任一状态进入，死亡状态为终止状态
+	m.AddTransition(fsm.AnyState, PlayerDead, func() bool { return p.HasTags("isDead") })
+	m.AddTransition(fsm.AnyState, PlayerHurt, func() bool { return p.hurt && !m.Is(PlayerDead) })
+
+	// 攀爬与游泳可从攻击与受击以外的任一状态进入，移动方式恢复常规时下落
+	interruptible := func() bool { return !m.Is(PlayerDead) && !m.Is(PlayerHurt) && !m.Is(PlayerAttack) }
+	m.AddTransition(fsm.AnyState, PlayerClimb, func() bool { return p.region.mode == MoveClimb && interruptible() })
+	m.AddTransition(fsm.AnyState, PlayerSwim, func() bool { return p.region.mode == MoveSwim && interruptible() })
+	m.AddTransition(PlayerClimb, PlayerFall, func() bool { return p.region.mode != MoveClimb })
+	m.AddTransition(PlayerSwim, PlayerFall, func() bool { return p.region.mode != MoveSwim })
+
+	for _, from := range []string{PlayerIdle, PlayerRun, PlayerLand} {
+		m.AddTransition(from, PlayerAttack, func() bool { return p.attacked })
+		m.AddTransition(from, PlayerJump, rising)
+		m.AddTransition(from, PlayerFall, falling)
+	}
+	m.AddTransition(PlayerIdle, PlayerRun, moving)
+	m.AddTransition(PlayerRun, PlayerIdle, stopped)
+
+	m.AddTransition(PlayerJump, PlayerAttack, func() bool { return p.attacked })
+	m.AddTransition(PlayerJump, PlayerLand, func() bool { return p.onGround })
+	m.AddTransition(PlayerJump, PlayerFall, falling)
+	m.AddTransition(PlayerFall, PlayerAttack, func() bool { return p.attacked })
+	m.AddTransition(PlayerFall, PlayerLand, func() bool { return p.onGround })
+	m.AddTransition(PlayerFall, PlayerWallSlide, func() bool { return p.jump.wallSliding })
+
+	// 贴墙滑落时蹬墙跳进入跳跃状态，离开墙壁时恢复下落
+	m.AddTransition(PlayerWallSlide, PlayerLand, func() bool { return p.onGround })
+	m.AddTransition(PlayerWallSlide, PlayerJump, rising)
+	m.AddTransition(PlayerWallSlide, PlayerFall, func() bool { return !p.jump.wallSliding })
+
+	m.AddTransition(PlayerLand, PlayerRun, func() bool { return m.Elapsed() >= playerLandTime && moving() })
+	m.AddTransition(PlayerLand, PlayerIdle, func() bool { return m.Elapsed() >= playerLandTime })
+
+	// 攻击与受击状态持续一段时间后，根据是否着地恢复
+	for _, from := range []string{PlayerAttack, PlayerHurt} {
+		duration := float64(playerAttackTime)
+		if from == PlayerHurt {
+			duration = playerHurtTime
+		}
+		done := func() bool { return m.Elapsed() >= duration }
+		if from == PlayerAttack {
+			// 攻击状态下再次攻击时重新计时
+			m.AddTransition(from, from, func() bool { return p.attacked && m.Elapsed() > 0 })
+		}
+		m.AddTransition(from, PlayerFall, func() bool { return done() && airborne() })
+		m.AddTransition(from, PlayerRun, func() bool { return done() && moving() })
+		m.AddTransition(from, PlayerIdle, func() bool { return done() })
+	}
+
+	m.Start(PlayerIdle)
+	return m
+}
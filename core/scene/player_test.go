@@ -0,0 +1,55 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/resource"
+	"testing"
+)
+
+func TestPlayerClipsPerState(t *testing.T) {
+	move := []*resource.Texture2D{{ID: 1}, {ID: 2}, {ID: 3}}
+	stand := []*resource.Texture2D{{ID: 4}, {ID: 5}}
+	clips := newPlayerClips(move, stand)
+
+	seen := make(map[*AnimationClip]string)
+	for _, state := range playerStates {
+		c := clips[state]
+		if c == nil || len(c.Frames) == 0 {
+			t.Fatalf("state %s has no clip", state)
+		}
+		if other, ok := seen[c]; ok {
+			t.Errorf("states %s and %s share a clip", state, other)
+		}
+		seen[c] = state
+	}
+	if d := clips[PlayerDead]; len(d.Frames) != 1 || d.Frames[0] != stand[1] || d.Loop {
+		t.Errorf("dead clip %+v, want the last stand frame held", d)
+	}
+	for _, state := range []string{PlayerJump, PlayerLand, PlayerAttack} {
+		if clips[state].Loop {
+			t.Errorf("%s clip loops", state)
+		}
+	}
+}
+
+func TestPlayerStatePlaysClip(t *testing.T) {
+	p := NewPlayer(0, 0, 16, 32, 0.5, 1, nil, nil)
+	p.Clips = newPlayerClips([]*resource.Texture2D{{ID: 1}, {ID: 2}, {ID: 3}}, []*resource.Texture2D{{ID: 4}})
+
+	for _, state := range []string{PlayerJump, PlayerFall, PlayerAttack, PlayerHurt, PlayerDead} {
+		p.FSM.Set(state)
+		if p.animator.Clip() != p.Clips[state] {
+			t.Errorf("state %s plays the %v clip", state, p.animator.Clip())
+		}
+	}
+
+	// 再次攻击时重新进入攻击状态，攻击片段从头播放
+	p.FSM.Set(PlayerAttack)
+	p.animator.Update(playerAttackTime / 2)
+	if p.animator.Texture().ID == 1 {
+		t.Fatal("attack clip did not advance")
+	}
+	p.FSM.Set(PlayerAttack)
+	if p.animator.Texture().ID != 1 {
+		t.Errorf("re-entering attack shows frame %d, want the first frame", p.animator.Texture().ID)
+	}
+}
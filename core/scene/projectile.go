@@ -0,0 +1,260 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/pool"
+	"github.com/ClessLi/2d-game-engin/core/render"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Projectile, 投射物对象，以圆形作为形状对象，由场景统一推进飞行、命中与回收
+type Projectile struct {
+	resolv.Circle
+	// 最长存在时长，单位为秒，为 0 时不限
+	Lifetime float64
+	// 最远飞行距离，为 0 时不限
+	MaxRange float32
+	// 重力系数，与场景重力加速度相乘，为 0 时直线飞行
+	GravityScale float32
+	// 可穿透的目标数，命中目标数超过该值时投射物销毁
+	Pierce int
+	// 可反弹的次数，撞击 "solid" 形状对象超过该次数时投射物销毁
+	Bounce int
+	// 可命中的渲染层，为 0 时可命中全部渲染层
+	HitMask render.LayerMask
+	// 命中回调，可用于生成命中效果，撞击 "solid" 形状对象时 target 为 nil
+	OnHit func(s *Scene, p *Projectile, target resolv.Shape)
+
+	age       float64
+	travelled float32
+	pierced   int
+	bounced   int
+	hits      map[interface{}]bool
+	// 所属对象池，为 nil 时移除后不回收
+	pool *pool.Pool
+}
+
+// NewProjectile, Projectile 类实例初始化函数
+// 参数:
+//     x, y: 投射物坐标
+//     radius: 投射物半径
+//     textures: 渲染用 Texture 对象列表
+// 返回值:
+//     Projectile 类指针
+func NewProjectile(x, y, radius int32, textures []*resource.Texture2D) *Projectile {
+	c := resolv.NewCircle(x, y, radius, 0, 1.2, nil, textures)
+	p := &Projectile{
+		Circle: *c,
+		hits:   make(map[interface{}]bool),
+	}
+	p.SetLayer(render.LayerProjectiles)
+	return p
+}
+
+// NewProjectilePool, 投射物对象池初始化函数，归还的投射物将被重置
+// 参数:
+//     capacity: 空闲投射物容量上限
+//     newFn: 新建投射物函数
+// 返回值:
+//     pool.Pool 类指针
+func NewProjectilePool(capacity int, newFn func() *Projectile) *pool.Pool {
+	return pool.NewPool(
+		func() interface{} { return newFn() },
+		func(obj interface{}) { obj.(*Projectile).reset() },
+		capacity)
+}
+
+// acquireProjectile, 从对象池取得投射物的包内函数
+// 参数:
+//     pl: pool.Pool 类指针，由 NewProjectilePool 创建
+//     x, y: 投射物坐标
+// 返回值:
+//     Projectile 类指针
+func acquireProjectile(pl *pool.Pool, x, y int32) *Projectile {
+	p := pl.Acquire().(*Projectile)
+	p.pool = pl
+	p.SetXY(x, y)
+	p.StorePrevXY()
+	return p
+}
+
+// reset, Projectile 类重置运行状态的包内方法，保留尺寸、纹理与伤害定义对象以供复用
+func (p *Projectile) reset() {
+	p.ClearTags()
+	p.SetSpd(0, 0)
+	p.IsXReverse = false
+	p.Lifetime, p.MaxRange, p.GravityScale = 0, 0, 0
+	p.Pierce, p.Bounce = 0, 0
+	p.HitMask = 0
+	p.OnHit = nil
+	p.age, p.travelled = 0, 0
+	p.pierced, p.bounced = 0, 0
+	for k := range p.hits {
+		delete(p.hits, k)
+	}
+}
+
+// release, Projectile 类将投射物归还所属对象池的包内方法，重复调用无效
+func (p *Projectile) release() {
+	if p.pool == nil {
+		return
+	}
+	pl := p.pool
+	p.pool = nil
+	pl.Release(p)
+}
+
+// CanHit, Projectile 类判断能否命中目标的方法，目标须处于可命中的渲染层
+// 参数:
+//     target: resolv.Shape 接口对象
+// 返回值:
+//     bool 类型
+func (p *Projectile) CanHit(target resolv.Shape) bool {
+	return p.HitMask == 0 || p.HitMask.Has(layerOf(target))
+}
+
+// Hit, Projectile 类登记命中目标的方法，穿透飞行时同一目标只受一次伤害
+// 参数:
+//     target: 命中目标
+// 返回值:
+//     bool 类型，首次命中该目标时为 true
+func (p *Projectile) Hit(target interface{}) bool {
+	if p.hits[target] {
+		return false
+	}
+	p.hits[target] = true
+	return true
+}
+
+// Destroyed, Projectile 类判断投射物是否已销毁的方法
+// 返回值:
+//     bool 类型
+func (p *Projectile) Destroyed() bool {
+	return p.HasTags("destroy") || p.HasTags("destroyed")
+}
+
+// hitFilter, 限制可命中目标的攻击作用形状接口对象
+type hitFilter interface {
+	CanHit(target resolv.Shape) bool
+}
+
+// canHit, 判断攻击作用形状对象能否对目标造成伤害的包内函数，不会伤害同阵营的目标，可以时登记命中
+// 参数:
+//     source: 攻击作用形状对象
+//     target: 目标形状对象
+// 返回值:
+//     bool 类型
+func canHit(source, target resolv.Shape) bool {
+	if f, ok := source.(hitFilter); ok && !f.CanHit(target) {
+		return false
+	}
+	if sameTeam(source, target) {
+		return false
+	}
+	return registerHit(source, target)
+}
+
+// updateProjectiles, Scene 类推进场景内投射物的包内方法，处理重力、反弹、命中与超时回收；
+// 已销毁的投射物保留一次更新用于渲染命中画面，随后移除
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateProjectiles(delta float64) {
+	// 复用临时列表，避免每次更新分配内存
+	projectiles, solids := s.projectiles[:0], s.solids[:0]
+	for _, shape := range *s.Map {
+		if p, ok := shape.(*Projectile); ok {
+			projectiles = append(projectiles, p)
+		} else if shape.HasTags(tagsSolid...) && !shape.HasTags(tagsDestroyed...) {
+			solids = append(solids, shape)
+		}
+	}
+
+	for _, p := range projectiles {
+		if p.Destroyed() {
+			s.Remove(p)
+			continue
+		}
+		p.age += delta
+		if (p.Lifetime > 0 && p.age >= p.Lifetime) || (p.MaxRange > 0 && p.travelled >= p.MaxRange) {
+			s.Remove(p)
+			continue
+		}
+
+		p.SpeedY += s.Gravity * p.GravityScale
+		x, y := int32(p.SpeedX), int32(p.SpeedY)
+		impact := false
+		if res := solids.Resolve(p, x, 0); res.Colliding() {
+			x = res.ResolveX
+			impact = !s.bounce(p, &p.SpeedX)
+		}
+		p.X += x
+		if res := solids.Resolve(p, 0, y); res.Colliding() {
+			y = res.ResolveY
+			impact = !s.bounce(p, &p.SpeedY) || impact
+		}
+		p.Y += y
+		p.travelled += mgl32.Vec2{float32(x), float32(y)}.Len()
+
+		if impact {
+			s.projectileHit(p, nil)
+			continue
+		}
+		s.hits = s.damageEntities(p, s.hits[:0])
+		for i, target := range s.hits {
+			s.hits[i] = nil
+			if !p.Destroyed() {
+				s.projectileHit(p, target)
+			}
+		}
+	}
+	// 清空临时列表，以免持有已移除的形状对象
+	for i := range projectiles {
+		projectiles[i] = nil
+	}
+	for i := range solids {
+		solids[i] = nil
+	}
+	s.projectiles, s.solids = projectiles[:0], solids[:0]
+}
+
+// bounce, Scene 类使投射物在一个轴向上反弹的包内方法
+// 参数:
+//     p: Projectile 类指针
+//     spd: 该轴向速度指针
+// 返回值:
+//     bool 类型，反弹次数已用尽时为 false
+func (s *Scene) bounce(p *Projectile, spd *float32) bool {
+	if p.bounced >= p.Bounce {
+		return false
+	}
+	p.bounced++
+	*spd = -*spd
+	return true
+}
+
+// projectileHit, Scene 类处理投射物命中的包内方法，执行命中回调，撞击 "solid" 形状对象或穿透次数用尽时销毁投射物
+// 参数:
+//     p: Projectile 类指针
+//     target: 命中目标，撞击 "solid" 形状对象时为 nil
+func (s *Scene) projectileHit(p *Projectile, target resolv.Shape) {
+	if p.OnHit != nil {
+		p.OnHit(s, p, target)
+	}
+	s.emit(&ProjectileHit{Projectile: p, Target: target, X: p.X, Y: p.Y})
+	if target != nil {
+		p.pierced++
+		if p.pierced <= p.Pierce {
+			return
+		}
+	} else {
+		// 撞击时震动镜头
+		for _, c := range s.cameras() {
+			if s.isInCamera(c, p) {
+				c.AddTrauma(0.25)
+			}
+		}
+	}
+	p.SetSpd(0, 0)
+	p.AddTags("destroy")
+}
@@ -0,0 +1,63 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+// BenchmarkProjectileLifecycle, 每次迭代发射一枚子弹，推进投射物并移除超时的子弹，稳定后对象池应无需新建子弹
+func BenchmarkProjectileLifecycle(b *testing.B) {
+	sp := resolv.NewSpace()
+	for i := int32(0); i < 8; i++ {
+		wall := resolv.NewRectangle(i*100, 400, 50, 50, 0, 1, nil, nil)
+		wall.AddTags("solid")
+		sp.Add(wall)
+	}
+	s := NewScene(1000, 500, nil, sp, NewDefaultCamera(0, 0, 1000, 500), func() {})
+	s.initWorld()
+
+	lw := NewFireBolt()
+	lw.Lifetime = 0.1
+	const delta = 1.0 / 60
+	fire := func() {
+		bolt := lw.newBolt(0, 100, mgl32.Vec2{1, 0}, false)
+		s.Map.Add(bolt)
+		s.updateProjectiles(delta)
+		s.flushRemoved()
+	}
+	// 预热至投射物数量稳定
+	for i := 0; i < 60; i++ {
+		fire()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fire()
+	}
+}
+
+func TestProjectileGravity(t *testing.T) {
+	tests := []struct {
+		gravity, scale float32
+		want           float32
+	}{
+		{DefaultGravity, 0, 0},
+		{DefaultGravity, 1, 0.5},
+		{2, 0.25, 0.5},
+		{0, 1, 0},
+	}
+	for _, tt := range tests {
+		s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 1000, 500), func() {})
+		s.initWorld()
+		s.Gravity = tt.gravity
+		p := NewProjectile(100, 100, 4, nil)
+		p.GravityScale = tt.scale
+		s.Map.Add(p)
+		s.updateProjectiles(0.125)
+		if p.SpeedY != tt.want {
+			t.Errorf("gravity %v scale %v: speed %v, want %v", tt.gravity, tt.scale, p.SpeedY, tt.want)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"github.com/ClessLi/2d-game-engin/resource"
+)
+
+// RegionKind, 区域类型
+type RegionKind int
+
+const (
+	// RegionClimbable, 可攀爬区域，如梯子与藤蔓
+	RegionClimbable RegionKind = iota
+	// RegionWater, 水域
+	RegionWater
+)
+
+// MovementMode, 玩家角色移动方式
+type MovementMode int
+
+const (
+	// MoveNormal, 受重力影响的常规移动
+	MoveNormal MovementMode = iota
+	// MoveClimb, 攀爬，无重力，可上下移动
+	MoveClimb
+	// MoveSwim, 游泳，受浮力与阻力影响，可划水
+	MoveSwim
+)
+
+// 离开攀爬后无法再次抓住可攀爬区域的时长，单位为秒
+const climbRegrabTime = 0.25
+
+// Region, 区域对象，作为含 "region" 标签的触发区域形状对象的 Data，玩家角色处于区域内时切换移动方式
+type Region struct {
+	Kind RegionKind
+	// 玩家角色进入与离开区域时的回调，均可为 nil
+	OnEnter func(s *Scene, p *Player)
+	OnExit  func(s *Scene, p *Player)
+}
+
+// regionState, 玩家角色区域与移动方式的运行状态
+type regionState struct {
+	mode MovementMode
+	// 本次更新中接触的触发区域，与上次更新的记录交替复用，避免每次更新分配内存
+	shapes, prevShapes map[resolv.Shape]bool
+	// 头部是否没入水中
+	submerged bool
+	// 头部没入水中的累计时长与溺水伤害计时
+	underwater, drown float64
+	// 无法再次抓住可攀爬区域的剩余时长
+	regrab float64
+}
+
+// NewRegion, 区域触发形状对象初始化函数
+// 参数:
+//     kind: 区域类型
+//     x, y: 区域坐标
+//     w, h: 区域尺寸
+//     textures: 渲染用 Texture 对象名列表，为空时区域不渲染
+// 返回值:
+//     resolv.Rectangle 类指针
+func NewRegion(kind RegionKind, x, y, w, h int32, textures ...string) *resolv.Rectangle {
+	r := resolv.NewRectangle(x, y, w, h, 0, 1, nil, resource.GetTexturesByName(textures...))
+	r.AddTags("region")
+	if len(textures) == 0 {
+		r.AddTags("hide")
+	}
+	r.SetData(&Region{Kind: kind})
+	return r
+}
+
+// NewLadder, 可攀爬区域触发形状对象初始化函数，用于梯子与藤蔓
+// 参数:
+//     x, y: 区域坐标
+//     w, h: 区域尺寸
+//     textures: 渲染用 Texture 对象名列表，为空时区域不渲染
+// 返回值:
+//     resolv.Rectangle 类指针
+func NewLadder(x, y, w, h int32, textures ...string) *resolv.Rectangle {
+	return NewRegion(RegionClimbable, x, y, w, h, textures...)
+}
+
+// NewWater, 水域触发形状对象初始化函数
+// 参数:
+//     x, y: 区域坐标
+//     w, h: 区域尺寸
+//     textures: 渲染用 Texture 对象名列表，为空时区域不渲染
+// 返回值:
+//     resolv.Rectangle 类指针
+func NewWater(x, y, w, h int32, textures ...string) *resolv.Rectangle {
+	return NewRegion(RegionWater, x, y, w, h, textures...)
+}
+
+// regionOf, 获取形状对象区域定义的包内函数
+// 参数:
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     Region 类指针，形状对象不是区域时为 nil
+func regionOf(shape resolv.Shape) *Region {
+	if r, ok := shape.GetData().(*Region); ok {
+		return r
+	}
+	return nil
+}
+
+// Mode, Player 类获取当前移动方式的方法
+// 返回值:
+//     MovementMode 类型
+func (p *Player) Mode() MovementMode {
+	return p.region.mode
+}
+
+// Breath, Player 类获取憋气状态的方法，用于 HUD 显示
+// 返回值:
+//     float64 类型，剩余憋气时长，不小于 0
+//     float64 类型，憋气总时长，不大于 0 时不限
+func (p *Player) Breath() (float64, float64) {
+	total := p.movement().BreathTime
+	left := total - p.region.underwater
+	if left < 0 {
+		left = 0
+	}
+	return left, total
+}
+
+// updateRegions, Scene 类检测玩家角色进出区域并切换移动方式的包内方法，
+// 角色中心处于水域内时游泳，处于可攀爬区域内并按下上移（空中时也可按下移）时攀爬
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateRegions(delta float64) {
+	p := s.Player
+	st := &p.region
+	cx, cy := p.Center()
+	climbable, water, submerged := false, false, false
+
+	shapes := st.prevShapes
+	if shapes == nil {
+		shapes = make(map[resolv.Shape]bool)
+	}
+	for shape := range shapes {
+		delete(shapes, shape)
+	}
+	colliding := s.Map.FilterByTags("region").GetCollidingShapes(p)
+	for i := 0; i < colliding.Length(); i++ {
+		shape := colliding.Get(i)
+		r := regionOf(shape)
+		if r == nil {
+			continue
+		}
+		shapes[shape] = true
+		if !st.shapes[shape] && r.OnEnter != nil {
+			r.OnEnter(s, p)
+		}
+
+		x1, y1 := shape.GetXY()
+		x2, y2 := shape.GetXY2()
+		if cx < x1 || cx > x2 {
+			continue
+		}
+		switch r.Kind {
+		case RegionClimbable:
+			climbable = true
+		case RegionWater:
+			if cy >= y1 && cy <= y2 {
+				water = true
+			}
+			if p.Y >= y1 && p.Y <= y2 {
+				submerged = true
+			}
+		}
+	}
+	for shape := range st.shapes {
+		if !shapes[shape] {
+			if r := regionOf(shape); r != nil && r.OnExit != nil {
+				r.OnExit(s, p)
+			}
+		}
+	}
+	st.shapes, st.prevShapes = shapes, st.shapes
+	st.submerged = submerged
+	if st.regrab > 0 {
+		st.regrab -= delta
+	}
+
+	mode := MoveNormal
+	switch {
+	case p.HasTags("isDead"):
+	case water:
+		mode = MoveSwim
+	case climbable && st.mode == MoveClimb:
+		// 在地面上按下移时离开攀爬
+		if !(p.onGround && s.Input.Pressed(input.ActionMoveDown)) {
+			mode = MoveClimb
+		}
+	case climbable && st.regrab <= 0:
+		if s.Input.Pressed(input.ActionMoveUp) || (!p.onGround && s.Input.Pressed(input.ActionMoveDown)) {
+			mode = MoveClimb
+		}
+	}
+	if mode != st.mode {
+		s.setMode(mode)
+	}
+	s.updateBreath(delta)
+}
+
+// setMode, Scene 类切换玩家角色移动方式的包内方法
+// 参数:
+//     mode: MovementMode 类型
+func (s *Scene) setMode(mode MovementMode) {
+	p := s.Player
+	p.region.mode = mode
+	p.jump = jumpState{}
+	switch mode {
+	case MoveClimb:
+		p.SetSpd(0, 0)
+		s.endAbility()
+	case MoveSwim:
+		// 入水时减缓下落
+		p.SpeedY *= p.movement().SwimDrag
+		s.endAbility()
+	}
+}
+
+// updateBreath, Scene 类推进玩家角色憋气与溺水的包内方法，头部露出水面时立即恢复
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateBreath(delta float64) {
+	p := s.Player
+	st := &p.region
+	m := p.movement()
+	if !st.submerged || m.BreathTime <= 0 || p.HasTags("isDead") {
+		st.underwater, st.drown = 0, 0
+		return
+	}
+	st.underwater += delta
+	if st.underwater < m.BreathTime {
+		return
+	}
+	st.drown -= delta
+	if st.drown > 0 {
+		return
+	}
+	st.drown = m.DrownInterval
+	if s.hurtPlayer(ecs.Damage{Amount: m.DrownDamage}) {
+		for _, c := range s.cameras() {
+			c.AddTrauma(0.2)
+		}
+	}
+}
+
+// climbMove, Scene 类玩家角色攀爬移动的包内方法
+func (s *Scene) climbMove() {
+	p := s.Player
+	speed := p.movement().ClimbSpeed
+	h := s.Input.Axis(input.AxisMoveX)
+	v := float32(0)
+	if s.Input.Pressed(input.ActionMoveUp) {
+		v--
+	}
+	if s.Input.Pressed(input.ActionMoveDown) {
+		v++
+	}
+	if h != 0 {
+		p.IsXReverse = h < 0
+	}
+	p.IsMove = h != 0 || v != 0
+	p.SetSpd(h*speed, v*speed)
+}
+
+// climbJump, Scene 类玩家角色跳离攀爬的包内方法，跳跃与上移共用按键时需同时按住左右移动方向
+func (s *Scene) climbJump() {
+	p := s.Player
+	if !s.Input.JustPressed(input.ActionJump) || p.HasTags("isDead") {
+		return
+	}
+	h := s.Input.Axis(input.AxisMoveX)
+	if s.Input.Pressed(input.ActionMoveUp) && h == 0 {
+		return
+	}
+	m := p.movement()
+	s.setMode(MoveNormal)
+	p.region.regrab = climbRegrabTime
+	p.IsMove = true
+	p.SetSpd(h*m.ClimbSpeed, -m.JumpSpeed)
+	p.jump.jumping = true
+}
+
+// swimMove, Scene 类玩家角色游泳移动的包内方法，速度受阻力衰减
+func (s *Scene) swimMove() {
+	p := s.Player
+	m := p.movement()
+	p.SpeedX *= m.SwimDrag
+	p.SpeedY *= m.SwimDrag
+
+	h := s.Input.Axis(input.AxisMoveX)
+	if h != 0 && !p.HasTags("isDead") {
+		p.IsXReverse = h < 0
+		p.IsMove = true
+		p.SpeedX += h * m.SwimAccel
+	}
+	if s.Input.Pressed(input.ActionMoveUp) {
+		p.SpeedY -= m.SwimAccel
+	}
+	if s.Input.Pressed(input.ActionMoveDown) {
+		p.SpeedY += m.SwimAccel
+	}
+	if p.SpeedX > m.SwimMaxSpeed {
+		p.SpeedX = m.SwimMaxSpeed
+	}
+	if p.SpeedX < -m.SwimMaxSpeed {
+		p.SpeedX = -m.SwimMaxSpeed
+	}
+}
+
+// swimStroke, Scene 类玩家角色划水的包内方法，头部露出水面时以起跳速度跃出水面
+func (s *Scene) swimStroke() {
+	p := s.Player
+	if !s.Input.JustPressed(input.ActionJump) || p.HasTags("isDead") {
+		return
+	}
+	m := p.movement()
+	p.IsMove = true
+	if p.region.submerged {
+		p.SpeedY = -m.SwimStroke
+		return
+	}
+	p.SpeedY = -m.JumpSpeed
+	p.jump.jumping = true
+}
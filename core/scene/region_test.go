@@ -0,0 +1,64 @@
+package scene
+
+import (
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/resolv"
+	"strings"
+	"testing"
+)
+
+func TestRegionEnterExit(t *testing.T) {
+	s := NewScene(1000, 500, nil, resolv.NewSpace(), NewDefaultCamera(0, 0, 320, 240), func() {})
+	s.Player = NewPlayer(400, 30, 16, 32, 0.5, 1, nil, nil)
+	water := NewWater(0, 0, 100, 100)
+	ladder := NewLadder(200, 0, 50, 100)
+	s.Map.Add(s.Player, water, ladder)
+
+	var log []string
+	for name, shape := range map[string]*resolv.Rectangle{"water": water, "ladder": ladder} {
+		name := name
+		r := regionOf(shape)
+		r.OnEnter = func(s *Scene, p *Player) { log = append(log, "enter "+name) }
+		r.OnExit = func(s *Scene, p *Player) { log = append(log, "exit "+name) }
+	}
+
+	tests := []struct {
+		name string
+		x    int32
+		up   bool
+		mode MovementMode
+		log  []string
+	}{
+		{"outside", 400, false, MoveNormal, nil},
+		{"enter water", 40, false, MoveSwim, []string{"enter water"}},
+		{"stay in water", 50, false, MoveSwim, nil},
+		{"leave water", 400, false, MoveNormal, []string{"exit water"}},
+		{"on ladder", 217, false, MoveNormal, []string{"enter ladder"}},
+		{"grab ladder", 217, true, MoveClimb, nil},
+		{"keep climbing", 217, false, MoveClimb, nil},
+		{"ladder to water", 40, false, MoveSwim, []string{"enter water", "exit ladder"}},
+		{"leave water again", 400, false, MoveNormal, []string{"exit water"}},
+	}
+	for _, tt := range tests {
+		log = nil
+		if tt.up {
+			s.Input.SetKeyDown(input.KeyUp)
+		} else {
+			s.Input.ReleaseKey(input.KeyUp)
+		}
+		s.Input.Update(0)
+		s.Player.SetXY(tt.x, 30)
+		s.updateRegions(0.125)
+		if s.Player.Mode() != tt.mode {
+			t.Errorf("%s: mode %v, want %v", tt.name, s.Player.Mode(), tt.mode)
+		}
+		if strings.Join(log, ", ") != strings.Join(tt.log, ", ") {
+			t.Errorf("%s: callbacks %v, want %v", tt.name, log, tt.log)
+		}
+	}
+
+	st := &s.Player.region
+	if st.shapes == nil || st.prevShapes == nil || len(st.shapes) != 0 || len(st.prevShapes) != 1 {
+		t.Errorf("region maps not swapped: %v, %v", st.shapes, st.prevShapes)
+	}
+}
@@ -4,24 +4,66 @@
 package scene
 
 import (
+	"github.com/ClessLi/2d-game-engin/core/ecs"
+	"github.com/ClessLi/2d-game-engin/core/event"
+	"github.com/ClessLi/2d-game-engin/core/input"
 	"github.com/ClessLi/2d-game-engin/core/render"
 	"github.com/ClessLi/2d-game-engin/core/resolv"
 	"github.com/ClessLi/2d-game-engin/resource"
-	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"sort"
 )
 
+// DefaultGravity, 场景默认重力加速度，与默认移动参数的重力一致
+const DefaultGravity = 0.5
+
 type Scene struct {
 	Player *Player
 	Map    *resolv.Space
+	// 实体世界，与 Map 共享碰撞形状
+	World *ecs.World
 	//精灵渲染器
 	renderer *render.SpriteRenderer
-	//摄像头
-	Camera     *Camera
-	Keys       [1024]bool
-	LockedKeys [1024]bool
-	Init       func()
-	W, H       float32
+	//摄像头，主镜头，用于鼠标坐标换算的默认镜头
+	Camera *Camera
+	// 全部镜头，按渲染顺序排列，为空时仅使用主镜头
+	Cameras []*Camera
+	// 窗口尺寸，用于换算各镜头视口，为零值时取主镜头尺寸
+	WindowW, WindowH float32
+	// 渲染层列表，决定形状对象的渲染顺序与可见性
+	Layers *RenderLayers
+	// 视差背景层，按加入顺序先于场景形状对象渲染，位于形状对象下方
+	Backgrounds []*BackgroundLayer
+	// 敌人生成器
+	Spawners []*Spawner
+	// 输入管理器，按动作名称查询玩家输入
+	Input *input.Manager
+	// 事件总线，场景事件见 EventPlayerDied 等常量，重置关卡时清空
+	Events *event.Bus
+	// 资源加载函数，仅在 Create 时调用一次，可为 nil
+	Load func()
+	// 关卡构建函数，在 Create 与 Reset 时调用
+	Init func()
+	W, H float32
+	// 重力加速度，作用于敌人与投射物，玩家角色的重力由其移动参数决定
+	Gravity float32
+
+	// 最近激活的存档点记录，玩家角色死亡后在此重生
+	Checkpoint *CheckpointState
+	// 存档点激活回调
+	OnCheckpoint func(cp *Checkpoint)
+	// 重生配置，为 nil 时角色死亡后不会自动重生
+	RespawnConfig *RespawnConfig
+	respawn       respawnState
+	// 待移除的形状对象，于每次更新结束时统一移除
+	removed []resolv.Shape
+	// 推进投射物、敌人与近战判定框时复用的临时列表，避免每次更新分配内存
+	projectiles []*Projectile
+	enemies     []*Enemy
+	hitboxes    []*Hitbox
+	solids      resolv.Space
+	hits        []resolv.Shape
 }
 
 // NewScene, 初始化 Scene 类实例函数
@@ -39,17 +81,23 @@ func NewScene(sceneW, sceneH float32, p *Player, sp *resolv.Space, camera *Camer
 		sp.Add(p)
 	}
 
-	return &Scene{
-		Player:     p,
-		Map:        sp,
-		renderer:   nil,
-		Camera:     camera,
-		Keys:       [1024]bool{},
-		LockedKeys: [1024]bool{},
-		Init:       init,
-		W:          sceneW,
-		H:          sceneH,
+	s := &Scene{
+		Player:   p,
+		Map:      sp,
+		renderer: nil,
+		Camera:   camera,
+		Layers:   NewDefaultRenderLayers(),
+		Input:    input.NewDefaultManager(),
+		Events:   event.NewBus(),
+		Init:     init,
+		W:        sceneW,
+		H:        sceneH,
+		Gravity:  DefaultGravity,
+
+		RespawnConfig: NewDefaultRespawnConfig(),
 	}
+	s.subscribeRespawn()
+	return s
 }
 
 // resetSceneSize, Scene 类重置场景边界的包内方法
@@ -73,30 +121,68 @@ func (s *Scene) Create() {
 	//初始化精灵渲染器
 	s.renderer = render.NewSpriteRenderer(shader)
 
+	// 加载资源
+	if s.Load != nil {
+		s.Load()
+	}
+
 	// 初始化地图
 	s.Init()
 
+	// 初始化实体世界
+	s.initWorld()
+
 	//设置投影
-	// mgl32.Ortho(0, --投影宽度, --投影高度, 0, -1, 1)
-	projection := mgl32.Ortho(0, s.Camera.W, s.Camera.H, 0, -1, 1)
+	projection := s.Camera.GetProjection()
 	shader.SetMatrix4fv("projection", &projection[0])
 }
 
 // Update, Scene 类场景更新方法
 // TODO: 定义游戏场景接口，并将其作为接口方法实现
 func (s *Scene) Update(delta float64) {
-	s.Player.SpeedY += 0.5
+	// 记录本次更新前的坐标，用于渲染插值
+	s.storePrevXY()
+	for _, c := range s.cameras() {
+		c.StorePrevXY()
+	}
+
+	// 上次更新中销毁的形状对象标记为已销毁，于本次更新结束时移除，不依赖渲染帧数
+	s.markDestroyed()
+
+	// 更新输入动作状态
+	s.Input.Update(delta)
+
+	// 按下重新开始时重置关卡，本次更新不再推进
+	if s.Input.JustPressed(input.ActionRestart) {
+		s.Reset()
+		return
+	}
+
+	// 未经 Create 初始化（如无渲染环境下回放）时补充初始化实体世界
+	s.initWorld()
+
+	s.updateRegions(delta)
+	s.playerGravity()
 
-	// 更新移动物体
+	// 更新移动物体与投射物
 	s.updateMove()
+	s.updateProjectiles(delta)
+	s.updateParticles(delta)
+
+	// 更新实体世界
+	s.World.Update(delta)
+
+	// 生成并更新敌人
+	s.updateSpawners(delta)
+	s.updateEnemies(delta)
+
+	// 更新背景层自动滚动
+	for _, b := range s.Backgrounds {
+		b.Update(delta)
+	}
 
 	// Check for a collision downwards by just attempting a resolution downwards and seeing if it collides with something.
-	down := s.Map.Filter(func(shape resolv.Shape) bool {
-		if (shape.HasTags("solid") || shape.HasTags("ramp")) && !shape.HasTags("destroyed") {
-			return true
-		}
-		return false
-	}).Resolve(s.Player, 0, 4)
+	down := s.playerGround()
 	onGround := down.Colliding()
 	s.Player.IsMove = false
 
@@ -104,29 +190,28 @@ func (s *Scene) Update(delta float64) {
 	s.playerMove(down)
 
 	// JUMP
-	s.playerJump(onGround)
+	s.playerJump(onGround, delta)
+
+	// 调整攻击矢量并更新技能
+	s.playerAim()
+	s.updateAbilities(delta)
 
 	// Attack
 	s.playerAttack(delta)
 
-	if !s.Player.IsMove {
-		s.Player.ToStand(float32(delta))
-	} else {
-		s.Player.ToMove(float32(delta))
-	}
-
 	x := int32(s.Player.SpeedX)
 	y := int32(s.Player.SpeedY)
 
 	solids := s.Map.FilterByTags("solid")
 	ramps := s.Map.FilterByTags("ramp")
-	dangers := s.Map.FilterByTags("dangerous")
-
-	//fmt.Println("check player is dead or not.")
-	// 判断用户是否已死亡
-	if res := dangers.Resolve(s.Player, x, y); res.Colliding() {
-		//fmt.Println("player is dead.")
-		s.Player.AddTags("isDead")
+	hazards := s.Map.Filter(func(shape resolv.Shape) bool {
+		h := hazardOf(shape)
+		return shape != s.Player && h != nil && h.Owner != interface{}(s.Player) && !shape.HasTags("destroy") && !shape.HasTags("destroyed")
+	})
+
+	// 判断用户是否受到伤害
+	if res := hazards.Resolve(s.Player, x, y); res.Colliding() {
+		s.damagePlayer(res.ShapeB)
 	}
 
 	// X-movement. We only want to collide with solid objects (not ramps) because we want to be able to move up them
@@ -145,7 +230,7 @@ func (s *Scene) Update(delta float64) {
 	// We look for ramps a little aggressively downwards because when walking down them, we want to stick to them.
 	// If we didn't do this, then you would "bob" when walking down the ramp as the Player moves too quickly out into
 	// space for gravity to push back down onto the ramp.
-	res := ramps.Resolve(s.Player, 0, y+4)
+	res := ramps.Resolve(s.Player, 0, y+s.Player.movement().GroundProbe)
 
 	if y < 0 || (res.Teleporting && res.ResolveY < -s.Player.H/2) {
 		res = resolv.Collision{}
@@ -162,46 +247,51 @@ func (s *Scene) Update(delta float64) {
 
 	s.Player.Y += y
 
-	//if s.Player.HasTags("isDead") {
-	//	s.Player.SpeedX = 0
-	//}
+	// 更新角色状态与动画
+	ground := s.playerGround()
+	s.Player.updateState(ground.Colliding(), delta)
 
-}
+	// 更新近战判定框与拾取物
+	s.updateHitboxes(delta)
+	s.updatePickups()
 
-// Draw, Scene 类场景渲染方法
-// TODO: 定义游戏场景接口，并将其作为接口方法实现
-func (s *Scene) Draw() {
+	// 存档点与重生
+	s.updateCheckpoints()
+	s.updateRespawn(delta)
 
-	resource.GetShader("sprite").SetMatrix4fv("view", s.Camera.GetViewMatrix())
-	// 若角色处于死亡状态，则调整角色 Texture 为死亡态的
-	if s.Player.HasTags("isDead") {
-		s.Player.Texture = resource.GetTexture("x")
+	// 镜头跟随
+	for _, c := range s.cameras() {
+		s.updateCamera(c, delta)
+		c.Update(delta)
 	}
-	s.Player.Draw(s.renderer)
 
-	//摄像头跟随
-	//playerSize := s.Player.GetSize()
-	Px, Py := s.Player.Center()
-	//screenX := float32(s.Player.X) - s.Camera.W/2 + playerSize[0]
-	//screenY := float32(s.Player.Y) - s.Camera.H/2 + playerSize[1]
-	screenX := float32(Px) - s.Camera.W/2
-	screenY := float32(Py) - s.Camera.H/2
-	//fmt.Printf("3) Px: %d, Py: %d, sx: %f, sy: %f\n", Px, Py, s.Camera.X, s.Camera.Y)
-	s.Camera.InPosition(screenX, screenY, s.W, s.H)
-	//fmt.Printf("4) Px: %d, Py: %d, sx: %f, sy: %f\n", Px, Py, s.Camera.X, s.Camera.Y)
+	// 派发本次更新中的场景事件，随后移除本次更新中销毁的形状对象
+	if s.Events != nil {
+		s.Events.Flush()
+	}
+	s.flushRemoved()
 
-	// TODO: 由于渲染依赖camera，暂时将space内各个对象渲染放在这个位置
-	for _, shape := range *s.Map {
-		if shape != s.Player && s.isInCamera(shape) && !shape.HasTags("hide") && !shape.HasTags("destroyed") && !shape.HasTags("init") {
-			shape.Draw(s.renderer)
-		}
+	//if s.Player.HasTags("isDead") {
+	//	s.Player.SpeedX = 0
+	//}
 
-		if shape.HasTags("destroy") {
-			shape.RemoveTags("destroy")
-			shape.AddTags("destroyed")
-		}
+}
 
+// Draw, Scene 类场景渲染方法
+// TODO: 定义游戏场景接口，并将其作为接口方法实现
+// 参数:
+//     alpha: 渲染插值系数，形状对象将渲染于上一次与当前逻辑状态之间的插值位置
+func (s *Scene) Draw(alpha float64) {
+	// 渲染结束后恢复形状对象的逻辑坐标
+	restore := s.interpolate(float32(alpha))
+	defer restore()
+
+	// 各镜头依次渲染至各自视口
+	windowW, windowH := s.windowSize()
+	for _, c := range s.cameras() {
+		s.drawCamera(c, float32(alpha), windowW, windowH)
 	}
+	render.SetViewport(0, 0, windowW, windowH, windowH)
 	//fmt.Println(s.Player.X, s.Player.Y, s.Camera.X, s.Camera.Y, s.Camera.W, s.Camera.H)
 
 	//if s.DrawHelpText {
@@ -215,167 +305,428 @@ func (s *Scene) Draw() {
 
 }
 
+// 每次更新逐个检查形状对象时使用的标签列表，展开传入 HasTags 以免每次调用分配内存
+var (
+	tagsSolid     = []string{"solid"}
+	tagsIsMove    = []string{"isMove"}
+	tagsDestroy   = []string{"destroy"}
+	tagsDestroyed = []string{"destroyed"}
+)
+
+// markDestroyed, Scene 类将含 "destroy" 标签的形状对象标记为 "destroyed" 的包内方法
+func (s *Scene) markDestroyed() {
+	for _, shape := range *s.Map {
+		if shape.HasTags(tagsDestroy...) {
+			shape.RemoveTags("destroy")
+			shape.AddTags("destroyed")
+		}
+	}
+}
+
+// Remove, Scene 类延迟移除形状对象的方法，形状对象于本次更新结束时移出场景空间，可在遍历场景空间时安全调用
+// 参数:
+//     shapes: resolv.Shape 接口对象列表
+func (s *Scene) Remove(shapes ...resolv.Shape) {
+	s.removed = append(s.removed, shapes...)
+}
+
+// flushRemoved, Scene 类移除待移除形状对象的包内方法，已销毁的 "isMove" 移动物体一并移除，来自对象池的形状对象归还对象池
+func (s *Scene) flushRemoved() {
+	for _, shape := range *s.Map {
+		if shape.HasTags(tagsIsMove...) && shape.HasTags(tagsDestroyed...) {
+			s.removed = append(s.removed, shape)
+		}
+	}
+	if len(s.removed) == 0 {
+		return
+	}
+	s.Map.Remove(s.removed...)
+	for i, shape := range s.removed {
+		// 来自对象池的形状对象移除后归还对象池
+		if p, ok := shape.(pooled); ok {
+			p.release()
+		}
+		s.removed[i] = nil
+	}
+	s.removed = s.removed[:0]
+}
+
+// pooled, 来自对象池的形状接口对象
+type pooled interface {
+	release()
+}
+
 // Destroy, Scene 类场景销毁方法
 // TODO: 定义游戏场景接口，并将其作为接口方法实现
 func (s *Scene) Destroy() {
 	s.Map.Clear()
 }
 
-// isInCamera, Scene 类判断 shape 对象是否在镜头内的包内方法
+// AddCamera, Scene 类添加镜头的方法，首个添加的镜头同时作为主镜头
 // 参数:
-//     shape: resolv.Shape 接口对象
-// 返回值:
-//     bool 类型， true 为在镜头内， false 为在镜头外
-func (s *Scene) isInCamera(shape resolv.Shape) bool {
-	//position := s.Camera.GetPosition()
-	//x := int32(position.X())
-	//y := int32(position.Y())
-	//cameraRec := resolv.NewRectangle(x, y, int32(s.Camera.W), int32(s.Camera.H), 0, 0, nil, nil)
-	cameraRec := resolv.NewRectangle(int32(s.Camera.X), int32(s.Camera.Y), int32(s.Camera.W), int32(s.Camera.H), 0, 0, nil, nil)
-	return cameraRec.IsColliding(shape)
-}
-
-// SetKeyDown, Scene 类设置控制器按键按下的方法
-// 参数:
-//     key: glfw.Key 类，对应控制器按键
-func (s *Scene) SetKeyDown(key glfw.Key) {
-	s.Keys[key] = true
+//     cameras: Camera 类指针列表
+func (s *Scene) AddCamera(cameras ...*Camera) {
+	s.Cameras = s.cameras()
+	for _, c := range cameras {
+		if s.Camera == nil {
+			s.Camera = c
+		}
+		if !containsCamera(s.Cameras, c) {
+			s.Cameras = append(s.Cameras, c)
+		}
+	}
 }
 
-// IsPressed, Scene 类判断控制器按键是否处于按下状况的方法
+// containsCamera, 判断镜头列表是否包含指定镜头的包内函数
 // 参数:
-//     keys: glfw.Key 类参数列表，对应查询按键
+//     cameras: Camera 类指针切片
+//     c: Camera 类指针
 // 返回值:
-//     bool 类型， true 为查询按键列表中存在处于按下状态的按键， false 为查询列表中不含按下状态的按键
-func (s *Scene) IsPressed(keys ...glfw.Key) bool {
-	for _, key := range keys {
-		if s.LockedKeys[key] {
+//     bool 类型
+func containsCamera(cameras []*Camera, c *Camera) bool {
+	for _, camera := range cameras {
+		if camera == c {
 			return true
 		}
 	}
 	return false
 }
 
-// PressedKey, Scene 类设置控制器按键为按下状态的方法
+// AddBackground, Scene 类添加视差背景层的方法，后加入的背景层覆盖于先加入的背景层之上
 // 参数:
-//     key: glfw.Key 类，对应控制器按键
-func (s *Scene) PressedKey(key glfw.Key) {
-	s.LockedKeys[key] = true
+//     layers: BackgroundLayer 类指针列表
+func (s *Scene) AddBackground(layers ...*BackgroundLayer) {
+	s.Backgrounds = append(s.Backgrounds, layers...)
 }
 
-// ReleaseKey, Scene 类设置控制器按键释放并解除按下状态的方法
-func (s *Scene) ReleaseKey(key glfw.Key) {
-	s.Keys[key] = false
-	s.LockedKeys[key] = false
+// cameras, Scene 类获取全部镜头的包内方法
+// 返回值:
+//     Camera 类指针切片，未添加镜头时仅含主镜头
+func (s *Scene) cameras() []*Camera {
+	if len(s.Cameras) == 0 && s.Camera != nil {
+		return []*Camera{s.Camera}
+	}
+	return s.Cameras
 }
 
-// HasOneKeyDown, Scene 类判断控制器按键列表中是否存在至少一个按键已按下的方法
-// 参数:
-//     keys: glfw.Key 类参数列表，对应查询按键
+// windowSize, Scene 类获取窗口尺寸的包内方法
 // 返回值:
-//     bool 类型， true 为存在， false 为不存在
-func (s *Scene) HasOneKeyDown(keys ...glfw.Key) bool {
-	for _, key := range keys {
-		if s.Keys[key] {
-			return true
-		}
+//     float32, float32 类型，未设置窗口尺寸时取主镜头尺寸
+func (s *Scene) windowSize() (float32, float32) {
+	if s.WindowW > 0 && s.WindowH > 0 {
+		return s.WindowW, s.WindowH
 	}
-	return false
+	return s.Camera.W, s.Camera.H
 }
 
-// playerJump, Scene 类玩家角色跳跃的包内方法
+// drawCamera, Scene 类以指定镜头渲染场景至其视口的包内方法
 // 参数:
-//     onGround: bool 类，角色是否着陆
-func (s *Scene) playerJump(onGround bool) {
-	if s.HasOneKeyDown(glfw.KeyUp, glfw.KeyW) && !s.IsPressed(glfw.KeyUp, glfw.KeyW) && onGround && !s.Player.HasTags("isDead") {
-		s.Player.IsMove = true
-		// 现在跳跃按键按下后重复跳跃
-		if s.HasOneKeyDown(glfw.KeyUp) {
-			s.PressedKey(glfw.KeyUp)
+//     c: Camera 类指针
+//     alpha: 渲染插值系数
+//     windowW, windowH: 窗口尺寸
+func (s *Scene) drawCamera(c *Camera, alpha, windowW, windowH float32) {
+	restore := c.Lerp(alpha)
+	defer restore()
+
+	vp := c.ViewportIn(windowW, windowH)
+	render.SetViewport(vp.X, vp.Y, vp.W, vp.H, windowH)
+	shader := resource.GetShader("sprite")
+	projection := c.GetProjection()
+	shader.SetMatrix4fv("projection", &projection[0])
+	shader.SetMatrix4fv("view", c.GetViewMatrix())
+	shader.SetFloat("brightness", s.brightness())
+
+	for _, b := range s.Backgrounds {
+		b.Draw(s.renderer, c)
+	}
+
+	if s.Layers == nil {
+		s.Layers = NewDefaultRenderLayers()
+	}
+	mask := c.LayerMask & s.Layers.VisibleMask()
+
+	// 按渲染层次序稳定排序，同一渲染层内保持加入场景的顺序
+	// TODO: 由于渲染依赖camera，暂时将space内各个对象渲染放在这个位置
+	shapes := make([]resolv.Shape, 0, s.Map.Length())
+	for _, shape := range *s.Map {
+		if mask.Has(layerOf(shape)) && isVisible(shape) && s.isInCamera(c, shape) && !shape.HasTags("hide") && !shape.HasTags("destroyed") && !shape.HasTags("init") {
+			shapes = append(shapes, shape)
 		}
-		if s.HasOneKeyDown(glfw.KeyW) {
-			s.PressedKey(glfw.KeyW)
+	}
+	sort.SliceStable(shapes, func(i, j int) bool {
+		return s.Layers.order(layerOf(shapes[i])) < s.Layers.order(layerOf(shapes[j]))
+	})
+
+	// 逐层渲染，每层先渲染实体世界中的精灵，再渲染形状对象
+	i := 0
+	drawn := render.LayerMask(0)
+	for order, layer := range s.Layers.layers {
+		s.World.LayerMask = mask & render.LayerMaskOf(layer.ID)
+		s.World.Draw(s.renderer)
+		for ; i < len(shapes) && s.Layers.order(layerOf(shapes[i])) == order; i++ {
+			shapes[i].Draw(s.renderer)
 		}
-		s.Player.SpeedY = -16
+		drawn |= render.LayerMaskOf(layer.ID)
+	}
+	// 未列入渲染层列表的渲染层
+	s.World.LayerMask = mask &^ drawn
+	s.World.Draw(s.renderer)
+	for ; i < len(shapes); i++ {
+		shapes[i].Draw(s.renderer)
 	}
 }
 
-// playerMove, Scene 类玩家角色移动的包内方法
+// updateCamera, Scene 类使镜头跟随其目标的包内方法
 // 参数:
-//     down: resolv.Collision 类，角色着陆点所在对象
-func (s *Scene) playerMove(down resolv.Collision) {
-	onGround := down.Colliding()
-	friction := float32(0.01)
-	if onGround {
-		ground := down.ShapeB
-		if ground.GetFriction() <= s.Player.GetFriction() {
-			friction = ground.GetFriction()
-		} else {
-			friction = s.Player.GetFriction()
-		}
+//     c: Camera 类指针
+//     delta: float64 类型，与上次更新的时延度量
+func (s *Scene) updateCamera(c *Camera, delta float64) {
+	target := c.Target
+	if target == nil {
+		target = s.Player
 	}
-	accel := s.Player.GetFriction() + friction
-
-	if s.Player.SpeedX > friction {
-		s.Player.SpeedX -= friction
-	} else if s.Player.SpeedX < -friction {
-		s.Player.SpeedX += friction
-	} else {
-		s.Player.SpeedX = 0
+	x1, y1 := target.GetXY()
+	x2, y2 := target.GetXY2()
+
+	// 目标朝向：玩家角色取其朝向，其他形状对象取其水平速度方向
+	isXReverse := false
+	if target == resolv.Shape(s.Player) {
+		isXReverse = s.Player.IsXReverse
+	} else if spdX, _ := target.GetSpd(); spdX < 0 {
+		isXReverse = true
 	}
 
-	if s.HasOneKeyDown(glfw.KeyLeft, glfw.KeyRight, glfw.KeyA, glfw.KeyD) {
-		s.Player.IsMove = true
+	down := s.Map.Filter(func(shape resolv.Shape) bool {
+		return shape != target && (shape.HasTags("solid") || shape.HasTags("ramp")) && !shape.HasTags("destroyed")
+	}).Resolve(target, 0, s.Player.movement().GroundProbe)
+	onGround := down.Colliding()
+
+	c.Follow(float32(x1+x2)/2, float32(y1+y2)/2, isXReverse, onGround, delta, s.W, s.H)
+}
+
+// initWorld, Scene 类初始化实体世界的包内方法，实体世界与场景地图共享同一空间
+func (s *Scene) initWorld() {
+	if s.World == nil || s.World.Space != s.Map {
+		s.World = ecs.NewWorld(s.Map)
+		s.World.AddSystem(ecs.NewDefaultSystems()...)
 	}
+}
 
-	if s.HasOneKeyDown(glfw.KeyRight, glfw.KeyD) && onGround && !s.Player.HasTags("isDead") {
-		s.Player.IsXReverse = false
-		s.Player.SpeedX += accel
+// storePrevXY, Scene 类记录场景内各形状对象当前坐标的包内方法，供渲染插值使用
+func (s *Scene) storePrevXY() {
+	for _, shape := range *s.Map {
+		if i, ok := shape.(resolv.Interpolated); ok {
+			i.StorePrevXY()
+		}
 	}
+}
 
-	if s.HasOneKeyDown(glfw.KeyLeft, glfw.KeyA) && onGround && !s.Player.HasTags("isDead") {
-		s.Player.IsXReverse = true
-		s.Player.SpeedX -= accel
+// interpolate, Scene 类将场景内各形状对象临时移动至插值坐标的包内方法
+// 参数:
+//     alpha: 渲染插值系数
+// 返回值:
+//     func() 类型，将各形状对象恢复至逻辑坐标的函数
+func (s *Scene) interpolate(alpha float32) func() {
+	type offset struct {
+		shape  resolv.Shape
+		dx, dy int32
 	}
+	offsets := make([]offset, 0)
+	for _, shape := range *s.Map {
+		i, ok := shape.(resolv.Interpolated)
+		if !ok {
+			continue
+		}
+		x, y := shape.GetXY()
+		lx, ly := i.LerpXY(alpha)
+		if lx != x || ly != y {
+			shape.Move(lx-x, ly-y)
+			offsets = append(offsets, offset{shape, lx - x, ly - y})
+		}
+	}
+	return func() {
+		for _, o := range offsets {
+			o.shape.Move(-o.dx, -o.dy)
+		}
+	}
+}
 
-	//fmt.Println(s.Player.SpeedX)
-	if s.Player.SpeedX > s.Player.GetMaxSpd() {
-		s.Player.SpeedX = s.Player.GetMaxSpd()
+// MouseWorldXY, Scene 类获取鼠标光标所在场景坐标的方法
+// 返回值:
+//     float32, float32 类型，场景坐标
+func (s *Scene) MouseWorldXY() (float32, float32) {
+	mx, my := s.Input.MousePosition()
+	windowW, windowH := s.windowSize()
+	// 取光标所在视口的镜头，后渲染的镜头优先，光标不在任何视口内时取主镜头
+	c := s.Camera
+	cameras := s.cameras()
+	for i := len(cameras) - 1; i >= 0; i-- {
+		if cameras[i].ViewportIn(windowW, windowH).Contains(mx, my) {
+			c = cameras[i]
+			break
+		}
 	}
+	return c.WindowToWorld(mx, my, windowW, windowH)
+}
 
-	if s.Player.SpeedX < -s.Player.GetMaxSpd() {
-		s.Player.SpeedX = -s.Player.GetMaxSpd()
+// PickShape, Scene 类获取场景坐标处形状对象的方法，用于鼠标点选
+// 参数:
+//     x, y: 场景坐标
+// 返回值:
+//     resolv.Shape 接口对象，取最后加入场景的形状对象，坐标处无形状对象时为 nil
+func (s *Scene) PickShape(x, y float32) resolv.Shape {
+	point := resolv.NewRectangle(int32(x), int32(y), 1, 1, 0, 0, nil, nil)
+	colliding := s.Map.GetCollidingShapes(point)
+	if colliding.Length() == 0 {
+		return nil
 	}
-	//fmt.Println(s.Player.SpeedX)
+	return colliding.Get(colliding.Length() - 1)
+}
+
+// isInCamera, Scene 类判断 shape 对象是否在镜头内的包内方法
+// 参数:
+//     c: Camera 类指针
+//     shape: resolv.Shape 接口对象
+// 返回值:
+//     bool 类型， true 为在镜头内， false 为在镜头外
+func (s *Scene) isInCamera(c *Camera, shape resolv.Shape) bool {
+	// 镜头缩放或旋转时取可视范围的外接矩形
+	x, y, w, h := c.Bounds()
+	cameraRec := resolv.NewRectangle(int32(x), int32(y), int32(math.Ceil(float64(w)))+1, int32(math.Ceil(float64(h)))+1, 0, 0, nil, nil)
+	return cameraRec.IsColliding(shape)
+}
+
+// playerGround, Scene 类获取玩家角色下方着陆点的包内方法
+// 返回值:
+//     resolv.Collision 类，角色向下的碰撞结果
+func (s *Scene) playerGround() resolv.Collision {
+	return s.Map.Filter(func(shape resolv.Shape) bool {
+		if (shape.HasTags("solid") || shape.HasTags("ramp")) && !shape.HasTags("destroyed") {
+			return true
+		}
+		return false
+	}).Resolve(s.Player, 0, s.Player.movement().GroundProbe)
 }
 
 // playerAttack, Scene 类玩家角色攻击的包内方法
 // 参数:
 //      delta: float64 类型，与上次更新的时延度量
 func (s *Scene) playerAttack(delta float64) {
-	s.Player.Weapon.CoolDown(delta)
+	s.Player.updateWeapons(delta)
 
-	// 调整角色攻击矢量
-	if s.Player.IsXReverse {
-		s.Player.AtkVec[0] = -1
-	} else {
-		s.Player.AtkVec[0] = 1
+	// 切换武器
+	inv := s.Player.Inventory
+	if s.Input.JustPressed(input.ActionNextWeapon) {
+		inv.Next()
+	}
+	if s.Input.JustPressed(input.ActionPrevWeapon) {
+		inv.Prev()
+	}
+	for i, action := range input.WeaponSlotActions {
+		if s.Input.JustPressed(action) {
+			inv.Select(i)
+		}
+	}
+	if s.Player.CurrentWeapon() == nil {
+		return
+	}
+
+	if s.Input.Pressed(input.ActionFire) && !s.Player.HasTags("isDead") {
+		if s.applyAttack(s.Player, s.Player.Attack()) {
+			s.Player.attacked = true
+		}
 	}
+}
 
-	if s.HasOneKeyDown(glfw.KeyUp, glfw.KeyW) {
-		s.Player.AtkVec[1] = -1
-	} else if s.HasOneKeyDown(glfw.KeyDown, glfw.KeyS) {
-		s.Player.AtkVec[1] = 1
+// playerAim, Scene 类调整玩家角色攻击矢量的包内方法，攻击矢量同时用于武器与技能的方向
+func (s *Scene) playerAim() {
+	if s.Input.Pressed(input.ActionMouseAim) {
+		// 鼠标瞄准时，攻击矢量指向光标所在场景坐标
+		s.aimAtMouse()
 	} else {
-		s.Player.AtkVec[1] = 0
+		if s.Player.IsXReverse {
+			s.Player.AtkVec[0] = -1
+		} else {
+			s.Player.AtkVec[0] = 1
+		}
+
+		if s.Input.Pressed(input.ActionAimUp) {
+			s.Player.AtkVec[1] = -1
+		} else if s.Input.Pressed(input.ActionAimDown) {
+			s.Player.AtkVec[1] = 1
+		} else {
+			s.Player.AtkVec[1] = 0
+		}
 	}
+}
+
+// aimAtMouse, Scene 类使玩家角色朝向并瞄准鼠标光标的包内方法
+func (s *Scene) aimAtMouse() {
+	mx, my := s.MouseWorldXY()
+	px, py := s.Player.Center()
+	vec := mgl32.Vec2{mx - float32(px), my - float32(py)}
+	if vec.Len() == 0 {
+		return
+	}
+	if !s.Player.HasTags("isDead") {
+		s.Player.IsXReverse = vec[0] < 0
+	}
+	s.Player.AtkVec = vec.Normalize()
+}
+
+// damagePlayer, Scene 类使玩家角色受到形状对象伤害的包内方法，伤害生效时震动镜头，伤害来源为移动物体时将其销毁
+// 参数:
+//     source: resolv.Shape 接口对象，伤害来源
+func (s *Scene) damagePlayer(source resolv.Shape) {
+	h := hazardOf(source)
+	if h == nil || !canHit(source, s.Player) || !s.hurtPlayer(damageFrom(h, source, s.Player)) {
+		return
+	}
+	trauma := float32(0.3)
+	if s.Player.HasTags("isDead") {
+		trauma = 0.6
+	}
+	for _, c := range s.cameras() {
+		c.AddTrauma(trauma)
+	}
+	if p, ok := source.(*Projectile); ok {
+		s.projectileHit(p, s.Player)
+	} else if source.HasTags("isMove") {
+		source.AddTags("destroy")
+	}
+}
 
-	if s.HasOneKeyDown(glfw.KeyJ, glfw.KeySpace) && !s.Player.HasTags("isDead") {
-		bolt := s.Player.Attack()
-		if bolt != nil {
-			s.Map.Add(bolt)
+// damageEntities, Scene 类使移动物体对其接触的实体及可受伤形状对象造成伤害的包内方法
+// 参数:
+//     shape: resolv.Shape 接口对象，移动物体
+//     hits: 用于追加伤害生效目标的列表，可为 nil
+// 返回值:
+//     []resolv.Shape 类型，追加伤害生效目标形状对象后的列表
+func (s *Scene) damageEntities(shape resolv.Shape, hits []resolv.Shape) []resolv.Shape {
+	h := hazardOf(shape)
+	if h == nil || shape.HasTags(tagsDestroy...) || shape.HasTags(tagsDestroyed...) {
+		return hits
+	}
+	for _, target := range *s.Map {
+		if target == shape || !shape.IsColliding(target) {
+			continue
+		}
+		if d, ok := target.(Damageable); ok {
+			// 玩家角色的受伤由 Update 中的伤害判定处理
+			if target != resolv.Shape(s.Player) && h.Owner != interface{}(target) && canHit(shape, target) && d.TakeDamage(damageFrom(h, shape, target)) {
+				hits = append(hits, target)
+			}
+			continue
+		}
+		e, ok := s.World.EntityOf(target)
+		if !ok || h.Owner == interface{}(e) || !canHit(shape, target) {
+			continue
+		}
+		if s.World.Damage(e, damageFrom(h, shape, target)) {
+			hits = append(hits, target)
 		}
 	}
+	return hits
 }
 
 // updateMove, Scene 类 Update() 方法调用，用于更新“移动物体”位置的包内方法
@@ -390,7 +741,18 @@ func (s *Scene) updateMove() {
 			y = float32(res.ResolveY)
 			shape.SetSpd(x, y)
 			shape.AddTags("destroy")
+			// 移动物体撞击时震动镜头
+			for _, c := range s.cameras() {
+				if s.isInCamera(c, shape) {
+					c.AddTrauma(0.25)
+				}
+			}
 		}
 		shape.SetXY(X+int32(x), Y+int32(y))
+
+		// 移动物体命中实体时造成伤害并销毁
+		if len(s.damageEntities(shape, nil)) > 0 {
+			shape.AddTags("destroy")
+		}
 	}
 }
@@ -0,0 +1,107 @@
+package scene
+
+import "github.com/ClessLi/2d-game-engin/core/pool"
+
+// Spawner, 敌人生成器对象，按间隔在指定位置生成敌人，敌人由对象池复用
+type Spawner struct {
+	Pool *pool.Pool
+	// 生成位置，为敌人底部中央坐标
+	X, Y int32
+	// 生成间隔，单位为秒
+	Interval float64
+	// 同时存活的敌人数量上限
+	MaxAlive int
+	// 敌人生成回调，可用于配置行为与武器，可为 nil
+	OnSpawn func(e *Enemy)
+	timer   float64
+	alive   int
+}
+
+// NewSpawner, Spawner 类实例初始化函数
+// 参数:
+//     x, y: 生成位置
+//     interval: 生成间隔
+//     maxAlive: 同时存活的敌人数量上限
+//     pl: pool.Pool 类指针，由 NewEnemyPool 创建
+// 返回值:
+//     Spawner 类指针
+func NewSpawner(x, y int32, interval float64, maxAlive int, pl *pool.Pool) *Spawner {
+	return &Spawner{Pool: pl, X: x, Y: y, Interval: interval, MaxAlive: maxAlive}
+}
+
+// Alive, Spawner 类获取由其生成且仍存活的敌人数量的方法
+// 返回值:
+//     int 类型
+func (sp *Spawner) Alive() int {
+	return sp.alive
+}
+
+// NewEnemyPool, 敌人对象池初始化函数，归还的敌人将恢复满生命值并清除运动状态
+// 参数:
+//     capacity: 空闲敌人容量上限
+//     newFn: 新建敌人函数
+// 返回值:
+//     pool.Pool 类指针
+func NewEnemyPool(capacity int, newFn func() *Enemy) *pool.Pool {
+	return pool.NewPool(
+		func() interface{} { return newFn() },
+		func(obj interface{}) { obj.(*Enemy).reset() },
+		capacity)
+}
+
+// reset, Enemy 类重置运行状态的包内方法
+func (e *Enemy) reset() {
+	e.Health.Revive(0)
+	e.SetSpd(0, 0)
+	e.stun = 0
+	e.onGround = false
+}
+
+// release, Enemy 类将敌人归还所属对象池的包内方法，重复调用无效
+func (e *Enemy) release() {
+	if e.spawner != nil {
+		e.spawner.alive--
+		e.spawner = nil
+	}
+	if e.pool == nil {
+		return
+	}
+	pl := e.pool
+	e.pool = nil
+	pl.Release(e)
+}
+
+// AddSpawner, Scene 类添加敌人生成器的方法
+// 参数:
+//     spawners: Spawner 类指针列表
+func (s *Scene) AddSpawner(spawners ...*Spawner) {
+	s.Spawners = append(s.Spawners, spawners...)
+}
+
+// updateSpawners, Scene 类推进敌人生成器的包内方法
+// 参数:
+//     delta: 与上次更新的时延
+func (s *Scene) updateSpawners(delta float64) {
+	for _, sp := range s.Spawners {
+		if sp.Pool == nil || sp.alive >= sp.MaxAlive {
+			sp.timer = 0
+			continue
+		}
+		sp.timer += delta
+		if sp.timer < sp.Interval {
+			continue
+		}
+		sp.timer = 0
+
+		e := sp.Pool.Acquire().(*Enemy)
+		e.pool = sp.Pool
+		e.spawner = sp
+		e.SetXY(sp.X-e.W/2, sp.Y-e.H)
+		e.StorePrevXY()
+		if sp.OnSpawn != nil {
+			sp.OnSpawn(e)
+		}
+		sp.alive++
+		s.Map.Add(e)
+	}
+}
@@ -1,7 +1,8 @@
 package scene
 
 import (
-	"fmt"
+	"github.com/ClessLi/2d-game-engin/core/pool"
+	"github.com/ClessLi/2d-game-engin/core/render"
 	"github.com/ClessLi/2d-game-engin/core/resolv"
 	"github.com/ClessLi/2d-game-engin/resource"
 	"github.com/go-gl/mathgl/mgl32"
@@ -9,8 +10,35 @@ import (
 
 // Weapon, 武器接口对象，定义武器攻击与冷却方法
 type Weapon interface {
-	Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) resolv.Shape
+	// Attack 发起攻击，冷却中时返回 nil
+	Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *AttackResult
 	CoolDown(delta float64)
+	// CoolDownState 获取剩余冷却时长与冷却总时长，用于 HUD 显示
+	CoolDownState() (float64, float64)
+}
+
+// Effect, 攻击附带效果，攻击生效后由场景执行，如镜头震动
+type Effect func(s *Scene)
+
+// AttackResult, 武器攻击结果对象，由场景统一处理：形状对象加入场景空间并以攻击者作为伤害发起者，随后依次执行附带效果
+type AttackResult struct {
+	// 攻击作用形状对象，如子弹、近战判定框
+	Shapes []resolv.Shape
+	// 攻击附带效果
+	Effects []Effect
+}
+
+// ShakeEffect, 生成震动全部镜头的攻击附带效果的函数
+// 参数:
+//     trauma: 镜头震动强度增量
+// 返回值:
+//     Effect 类
+func ShakeEffect(trauma float32) Effect {
+	return func(s *Scene) {
+		for _, c := range s.cameras() {
+			c.AddTrauma(trauma)
+		}
+	}
 }
 
 // LongRangeWeapon, 远程武器类，定义远程武器及其相关信息
@@ -20,33 +48,88 @@ type LongRangeWeapon struct {
 	CDDelta    float64
 	Speed      float32
 	BoltRadius int32
+	// 每次攻击发射的子弹数量，不大于 1 时为单发
+	Count int
+	// 多发子弹的总散布角度，单位为弧度，子弹均匀分布于攻击矢量两侧
+	Spread float32
+	// 子弹伤害数值与击退力度
+	Damage    int
+	Knockback float32
+	// 子弹最长存在时长与最远飞行距离，为 0 时不限
+	Lifetime float64
+	Range    float32
+	// 子弹重力系数
+	GravityScale float32
+	// 子弹可穿透的目标数与可反弹的次数
+	Pierce, Bounce int
+	// 子弹可命中的渲染层，为 0 时可命中全部渲染层
+	HitMask render.LayerMask
+	// 子弹命中回调
+	OnHit func(s *Scene, p *Projectile, target resolv.Shape)
+	// 子弹对象池，为 nil 时每次攻击新建子弹
+	Pool *pool.Pool
 }
 
-// Attack, LongRangeWeapon 类攻击方法， Weapon.Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) resolv.Shape 的实现
+// Attack, LongRangeWeapon 类攻击方法， Weapon.Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *AttackResult 的实现
 // 参数:
 //     X, Y: 攻击初始坐标
 //     vec2: 攻击矢量
 //     isXReverse: 图像是水平镜像向后的
 // 返回值:
-//     resolv.Shape 类，攻击作用形状对象
-func (lw *LongRangeWeapon) Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) resolv.Shape {
+//     AttackResult 类指针，含发射的全部子弹，冷却中时为 nil
+func (lw *LongRangeWeapon) Attack(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *AttackResult {
 	if lw.CDDelta > 0 {
 		return nil
 	}
 	lw.CDDelta = lw.CD
 
+	count := lw.Count
+	if count < 1 {
+		count = 1
+	}
+	result := &AttackResult{Shapes: make([]resolv.Shape, 0, count)}
+	for i := 0; i < count; i++ {
+		angle := float32(0)
+		if count > 1 {
+			angle = lw.Spread * (float32(i)/float32(count-1) - 0.5)
+		}
+		result.Shapes = append(result.Shapes, lw.newBolt(X, Y, mgl32.Rotate2D(angle).Mul2x1(vec2), isXReverse))
+	}
+	return result
+}
+
+// newBolt, LongRangeWeapon 类生成子弹的包内方法
+// 参数:
+//     X, Y: 子弹初始坐标
+//     vec2: 子弹飞行方向矢量
+//     isXReverse: 图像是水平镜像向后的
+// 返回值:
+//     Projectile 类指针
+func (lw *LongRangeWeapon) newBolt(X, Y int32, vec2 mgl32.Vec2, isXReverse bool) *Projectile {
 	SpdX, SpdY := lw.initSpd(vec2)
 
-	bolt := resolv.NewCircle(X, Y,
-		lw.BoltRadius,
-		0, 1.2,
-		nil,
-		resource.GetTexturesByName(lw.BoltName))
+	var bolt *Projectile
+	if lw.Pool != nil {
+		bolt = acquireProjectile(lw.Pool, X, Y)
+	} else {
+		bolt = NewProjectile(X, Y, lw.BoltRadius, resource.GetTexturesByName(lw.BoltName))
+	}
+	bolt.Lifetime = lw.Lifetime
+	bolt.MaxRange = lw.Range
+	bolt.GravityScale = lw.GravityScale
+	bolt.Pierce = lw.Pierce
+	bolt.Bounce = lw.Bounce
+	bolt.HitMask = lw.HitMask
+	bolt.OnHit = lw.OnHit
 
 	bolt.IsXReverse = isXReverse
+	if h, ok := bolt.GetData().(*Hazard); ok {
+		// 复用对象池中子弹的伤害定义对象
+		*h = Hazard{Damage: lw.Damage, Knockback: lw.Knockback}
+	} else {
+		bolt.SetData(&Hazard{Damage: lw.Damage, Knockback: lw.Knockback})
+	}
 	bolt.SetSpd(SpdX, SpdY)
-	bolt.AddTags("isMove")
-	fmt.Println("shooting, x:", bolt.X, "y:", bolt.Y, "spdX:", bolt.SpeedX, "spdY:", bolt.SpeedY)
 	return bolt
 }
 
@@ -57,6 +140,26 @@ func (lw *LongRangeWeapon) CoolDown(delta float64) {
 	lw.CDDelta -= delta
 }
 
+// CoolDownState, LongRangeWeapon 类获取冷却状态的方法， Weapon.CoolDownState() (float64, float64) 的实现
+// 返回值:
+//     float64 类型，剩余冷却时长，不小于 0
+//     float64 类型，冷却总时长
+func (lw *LongRangeWeapon) CoolDownState() (float64, float64) {
+	return coolDownLeft(lw.CDDelta), lw.CD
+}
+
+// coolDownLeft, 获取非负剩余冷却时长的包内函数
+// 参数:
+//     cdDelta: 冷却计时
+// 返回值:
+//     float64 类型
+func coolDownLeft(cdDelta float64) float64 {
+	if cdDelta < 0 {
+		return 0
+	}
+	return cdDelta
+}
+
 // initSpd, LongRangeWeapon 类定义子弹初速度的包内方法
 // 参数:
 //     vec2: 攻击方向矢量
@@ -67,15 +170,22 @@ func (lw *LongRangeWeapon) initSpd(vec2 mgl32.Vec2) (float32, float32) {
 	return vec2[0] * lw.Speed / vecLen, vec2[1] * lw.Speed / vecLen
 }
 
-// NewFireBolt, 火球武器实例化函数
+// NewFireBolt, 火球武器实例化函数，火球由武器自带的对象池复用
 // 返回值:
 //     LongRangeWeapon 类指针
 func NewFireBolt() *LongRangeWeapon {
-	return &LongRangeWeapon{
+	lw := &LongRangeWeapon{
 		BoltName:   "FireBolt",
 		CD:         1.0,
 		CDDelta:    0,
 		Speed:      20,
 		BoltRadius: 10,
+		Damage:     1,
+		Knockback:  6,
+		Lifetime:   3,
 	}
+	lw.Pool = NewProjectilePool(32, func() *Projectile {
+		return NewProjectile(0, 0, lw.BoltRadius, resource.GetTexturesByName(lw.BoltName))
+	})
+	return lw
 }
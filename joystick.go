@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// glfwJoysticks, 基于 glfw joystick 接口的手柄输入源， input.JoystickSource 的实现
+type glfwJoysticks struct{}
+
+// Present, glfwJoysticks 类判断手柄是否连接的方法
+func (glfwJoysticks) Present(joy int) bool {
+	return glfw.JoystickPresent(glfw.Joystick(joy))
+}
+
+// Name, glfwJoysticks 类获取手柄名称的方法
+func (glfwJoysticks) Name(joy int) string {
+	return glfw.GetJoystickName(glfw.Joystick(joy))
+}
+
+// Axes, glfwJoysticks 类获取手柄原始轴数值的方法
+func (glfwJoysticks) Axes(joy int) []float32 {
+	return glfw.GetJoystickAxes(glfw.Joystick(joy))
+}
+
+// Buttons, glfwJoysticks 类获取手柄原始按键状态的方法
+func (glfwJoysticks) Buttons(joy int) []bool {
+	raw := glfw.GetJoystickButtons(glfw.Joystick(joy))
+	buttons := make([]bool, len(raw))
+	for i, b := range raw {
+		buttons[i] = glfw.Action(b) == glfw.Press
+	}
+	return buttons
+}
+
+// JoystickCallback, 手柄插拔回调，收到事件后立即刷新手柄连接状态
+func JoystickCallback(joy, event int) {
+	game.Input.RefreshGamepads()
+}
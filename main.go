@@ -1,6 +1,11 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/loop"
+	"github.com/ClessLi/2d-game-engin/core/replay"
 	"github.com/ClessLi/2d-game-engin/resource/demo"
 	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/glfw/v3.2/glfw"
@@ -15,28 +20,51 @@ const (
 var (
 	windowName = "Test Game"
 	game       = demo.NewDemo(Width, Height)
-	deltaTime  = 0.0
-	lastFrame  = 0.0
+	gameLoop   = loop.NewDefaultLoop()
+	recordFile = flag.String("record", "", "record input to the given replay file")
+	replayFile = flag.String("replay", "", "play back input from the given replay file")
 )
 
 func main() {
+	flag.Parse()
 	runtime.LockOSThread()
 	window := initGlfw()
 	defer glfw.Terminate()
 	initOpenGL()
 	game.Create()
 
-	for !window.ShouldClose() {
-		currFrame := glfw.GetTime()
-		deltaTime = currFrame - lastFrame
-		lastFrame = currFrame
+	var updater loop.Updater = game
+	var recorder *replay.Recorder
+	switch {
+	case *replayFile != "":
+		rec, err := replay.Load(*replayFile)
+		if err != nil {
+			panic(err)
+		}
+		gameLoop.Step = rec.Step
+		playback := replay.NewPlayback(rec, game.Input, game, game.Map)
+		playback.OnFinish = func(match bool) {
+			fmt.Println("replay finished, final state matches recording:", match)
+		}
+		updater = playback
+	case *recordFile != "":
+		recorder = replay.NewRecorder(game.Input, gameLoop.Step)
+	}
 
+	for !window.ShouldClose() {
 		glfw.PollEvents()
-		game.Update(deltaTime)
+		// 以固定步长更新游戏逻辑，并按剩余时长插值渲染
+		alpha := gameLoop.Tick(glfw.GetTime(), updater)
 		gl.Clear(gl.COLOR_BUFFER_BIT)
-		game.Draw()
+		game.Draw(alpha)
 		window.SwapBuffers()
 	}
+
+	if recorder != nil {
+		if err := recorder.Finish(game.Input, game.Map).Save(*recordFile); err != nil {
+			panic(err)
+		}
+	}
 }
 
 func initOpenGL() {
@@ -58,6 +86,11 @@ func initGlfw() *glfw.Window {
 		panic(err)
 	}
 	window.SetKeyCallback(KeyCallback)
+	window.SetCursorPosCallback(CursorPosCallback)
+	window.SetMouseButtonCallback(MouseButtonCallback)
+	window.SetScrollCallback(ScrollCallback)
+	glfw.SetJoystickCallback(JoystickCallback)
+	game.Input.SetJoystickSource(glfwJoysticks{})
 
 	window.MakeContextCurrent()
 	return window
@@ -66,8 +99,25 @@ func initGlfw() *glfw.Window {
 func KeyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
 	switch action {
 	case glfw.Press:
-		game.SetKeyDown(key)
+		game.Input.SetKeyDown(input.Key(key))
 	case glfw.Release:
-		game.ReleaseKey(key)
+		game.Input.ReleaseKey(input.Key(key))
 	}
 }
+
+func CursorPosCallback(w *glfw.Window, xpos float64, ypos float64) {
+	game.Input.SetMousePosition(xpos, ypos)
+}
+
+func MouseButtonCallback(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	switch action {
+	case glfw.Press:
+		game.Input.SetMouseButtonDown(input.MouseButton(button))
+	case glfw.Release:
+		game.Input.ReleaseMouseButton(input.MouseButton(button))
+	}
+}
+
+func ScrollCallback(w *glfw.Window, xoff float64, yoff float64) {
+	game.Input.AddScroll(xoff, yoff)
+}
@@ -1,10 +1,17 @@
 package demo
 
 import (
+	"fmt"
+	"github.com/ClessLi/2d-game-engin/core/bt"
+	"github.com/ClessLi/2d-game-engin/core/event"
+	"github.com/ClessLi/2d-game-engin/core/input"
+	"github.com/ClessLi/2d-game-engin/core/render"
 	"github.com/ClessLi/2d-game-engin/core/resolv"
 	"github.com/ClessLi/2d-game-engin/core/scene"
 	"github.com/ClessLi/2d-game-engin/resource"
 	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
 )
 
 // NewDemo, 游戏 demo 版框架初始化函数
@@ -42,8 +49,10 @@ func NewDemo(w, h float32) *scene.Scene {
 		nil,
 		nil)
 
-	// 定义game.Init函数
-	game.Init = func() {
+	game.WindowW, game.WindowH = w, h
+
+	// 定义game.Load函数，仅在场景创建时加载一次资源
+	game.Load = func() {
 		//加载资源
 		resource.LoadTexture(gl.TEXTURE0, "./resource/image/platformLine.png", "platformLine")
 		resource.LoadTexture(gl.TEXTURE0, "./resource/image/firebolt.png", "FireBolt")
@@ -59,10 +68,34 @@ func NewDemo(w, h float32) *scene.Scene {
 		resource.LoadTexture(gl.TEXTURE0, "./resource/image/bat/5.png", "5")
 		resource.LoadTexture(gl.TEXTURE0, "./resource/image/bat/6.png", "6")
 		resource.LoadTexture(gl.TEXTURE0, "./resource/image/bat/7.png", "7")
+		resource.LoadTexture(gl.TEXTURE0, "./resource/image/background/sky.png", "sky")
+		resource.LoadTexture(gl.TEXTURE0, "./resource/image/background/hills.png", "hills")
+
+		// 加载按键绑定配置
+		if err := game.Input.LoadConfig("./resource/config/input.json"); err != nil {
+			panic(err)
+		}
+		if err := game.Input.LoadGamepadMappings("./resource/config/gamepads.json"); err != nil {
+			panic(err)
+		}
+	}
 
-		game.Map = resolv.NewSpace()
+	// 定义game.Init函数，重置关卡时复用同一场景空间重新构建
+	game.Init = func() {
+		if game.Map == nil {
+			game.Map = resolv.NewSpace()
+		}
 		game.Map.Clear()
 
+		// 敌人死亡时迸发火花，重置关卡会清空订阅，因此在构建关卡时订阅
+		burst := scene.NewParticleEmitter("FireBolt", 10, 64)
+		burst.Count = 12
+		burst.Spread = 2 * math.Pi
+		game.Events.Subscribe(scene.EventEnemyDied, func(e event.Event) {
+			x, y := e.(*scene.EnemyDied).Enemy.Center()
+			burst.Emit(game, x, y, mgl32.Vec2{0, -1})
+		})
+
 		// 创建游戏角色
 		player := scene.NewPlayer(
 			int32(sceneW)/2, int32(sceneH)/2,
@@ -72,11 +105,126 @@ func NewDemo(w, h float32) *scene.Scene {
 			[]string{"0", "1", "2", "3", "4", "5", "6", "7"},
 			[]string{"0", "1", "2", "3", "4", "5", "6", "7"})
 		player.SetMaxSpd(5)
-		player.Weapon = scene.NewFireBolt()
+		// 加载移动参数，允许空中转向、二段跳与蹬墙跳
+		movement, err := scene.LoadMovementProfile("./resource/config/movement.json")
+		if err != nil {
+			panic(err)
+		}
+		player.Movement = movement
+		// 技能：冲刺离地后仅可使用一次，下砸消耗魔力，按住滑翔
+		dash := scene.NewAbility("dash", input.ActionDash, 0.6, 0.15, scene.NewDash())
+		dash.AirLimit = 1
+		pound := scene.NewAbility("ground_pound", input.ActionGroundPound, 1, 0, scene.NewGroundPound())
+		pound.ManaCost = 2
+		glide := scene.NewAbility("glide", input.ActionGlide, 0, 0, scene.NewGlide())
+		glide.Hold = true
+		player.AddAbility(dash, pound, glide)
+		fireBolt := scene.NewFireBolt()
+		fireBolt.Pierce = 1
+		// 火球命中时迸发火花
+		sparks := scene.NewParticleEmitter("FireBolt", 8, 64)
+		fireBolt.OnHit = func(s *scene.Scene, p *scene.Projectile, target resolv.Shape) {
+			sparks.Emit(s, p.X, p.Y, mgl32.Vec2{-p.SpeedX, -p.SpeedY})
+		}
+		sword := scene.NewSword()
+		player.Inventory = scene.NewInventory(scene.NewMana(5, 1))
+		player.Inventory.Add(&scene.WeaponSlot{Name: "fire_bolt", Weapon: fireBolt, ManaCost: 1})
+		player.Inventory.Add(scene.NewWeaponSlot("sword", sword))
+		// 蝙蝠纹理帧按状态分配：振翅上升、滑翔下落、攻击、受击闪烁与死亡
+		player.SetClip(scene.PlayerJump, scene.NewAnimationClip(0.06, false, "0", "1", "2", "3"))
+		player.SetClip(scene.PlayerFall, scene.NewAnimationClip(0.12, true, "4", "5", "6", "7"))
+		player.SetClip(scene.PlayerAttack, scene.NewAnimationClip(0.06, false, "6", "7", "0"))
+		player.SetClip(scene.PlayerHurt, scene.NewAnimationClip(0.05, true, "x", "3"))
+		player.SetClip(scene.PlayerDead, scene.NewAnimationClip(0, false, "x"))
 		game.Camera = scene.NewDefaultCamera(float32(player.X), float32(player.Y), screenW, screenH)
+		game.Camera.FollowConfig = scene.NewDefaultCameraFollow()
+		// 小地图镜头，以画中画形式显示整个场景
+		minimap := scene.NewDefaultCamera(0, 0, game.W, game.H)
+		minimap.Viewport = scene.Viewport{X: w - 170, Y: 10, W: 160, H: 80}
+		minimap.Shake = nil
+		game.Cameras = nil
+		game.AddCamera(game.Camera, minimap)
 		game.Player = player
 		game.Map.Add(player)
 
+		// 视差背景：天空固定于镜头画面，远山以较慢速度水平滚动
+		sky := scene.NewBackgroundLayer(resource.GetTexture("sky"), 0, 0)
+		sky.H = screenH
+		hills := scene.NewBackgroundLayer(resource.GetTexture("hills"), 0.3, 1)
+		hills.W, hills.H = 512, 256
+		hills.Y = game.H - hills.H
+		game.Backgrounds = nil
+		game.AddBackground(sky, hills)
+
+		// 敌人
+		bat := []string{"0", "1", "2", "3", "4", "5", "6", "7"}
+		groundY := game.H - cellH*4
+		walker := scene.NewEnemy(int32(game.W/3), int32(groundY-60), 60, 60, 3, bat...)
+		chase := scene.NewChase(300, 3, scene.NewPatrol(2,
+			mgl32.Vec2{game.W / 4, groundY},
+			mgl32.Vec2{game.W / 2, groundY}))
+		chase.AttackRange = 80
+		walker.Behaviour = chase
+		walker.Weapon = scene.NewSword()
+		flyer := scene.NewEnemy(int32(game.W*2/3), int32(game.H/3), 50, 50, 2, bat...)
+		flyer.Flying = true
+		flyer.Behaviour = scene.NewFlyToward(2, 1.5, 4)
+		shooter := scene.NewEnemy(int32(game.W*7/8), int32(groundY-70), 70, 70, 4, bat...)
+		bolt := scene.NewFireBolt()
+		bolt.CD = 2
+		bolt.Range = 700
+		bolt.HitMask = render.LayerMaskOf(render.LayerActors)
+		shooter.Weapon = bolt
+		// 远程敌人以行为树驱动：玩家角色靠近时保持距离射击，否则在原地与出生点之间巡逻
+		shooterX, shooterY := shooter.Center()
+		shooter.Behaviour = scene.NewTreeBehaviour(bt.NewTree(&bt.Selector{
+			Reactive: true,
+			Children: []bt.Node{
+				&bt.Sequence{
+					Reactive: true,
+					Children: []bt.Node{
+						bt.NewCondition("player_near", scene.PlayerWithin(600)),
+						bt.NewAction("keep_distance", scene.BehaviourAction(scene.NewKeepDistance(350, 600, 2))),
+					},
+				},
+				bt.NewAction("patrol", scene.BehaviourAction(scene.NewPatrol(1,
+					mgl32.Vec2{float32(shooterX) - 100, float32(shooterY)},
+					mgl32.Vec2{float32(shooterX), float32(shooterY)}))),
+			},
+		}))
+		game.Map.Add(walker, flyer, shooter)
+
+		// 蝙蝠巢穴：每隔数秒生成一只飞行蝙蝠，同时最多存在两只
+		nest := scene.NewSpawner(int32(game.W/2), int32(game.H/4), 4, 2, scene.NewEnemyPool(4, func() *scene.Enemy {
+			e := scene.NewEnemy(0, 0, 40, 40, 1, bat...)
+			e.Flying = true
+			return e
+		}))
+		nest.OnSpawn = func(e *scene.Enemy) {
+			e.Behaviour = scene.NewFlyToward(1.5, 1, 3)
+		}
+		game.Spawners = nil
+		game.AddSpawner(nest)
+
+		// 散射火球拾取物，拾取后获得弹药有限的散射武器，再次拾取补充弹药
+		scatter := scene.NewFireBolt()
+		scatter.Count = 3
+		scatter.Spread = 0.5
+		scatter.CD = 0.6
+		game.Map.Add(scene.NewWeaponPickup(int32(game.W/2), int32(groundY-40), 30, 30,
+			&scene.WeaponSlot{Name: "scatter", Weapon: scatter, Ammo: 10, MaxAmmo: 20}, "FireBolt"))
+
+		// 存档点
+		for i, x := range []float32{game.W / 8, game.W * 3 / 4} {
+			checkpoint := scene.NewCheckpoint(
+				fmt.Sprintf("checkpoint-%d", i),
+				int32(x),
+				int32(game.H-cellH*4-100),
+				int32(cellW*4),
+				100)
+			game.Map.Add(checkpoint)
+		}
+
 		// A ramp
 		line := resolv.NewLine(
 			int32(game.W/4+cellW),
@@ -114,6 +262,14 @@ func NewDemo(w, h float32) *scene.Scene {
 		line.AddTags("ramp")
 		game.Map.Add(line)
 
+		// 通往平台的梯子
+		game.Map.Add(scene.NewLadder(
+			int32(game.W/4+cellW*25),
+			int32(game.H-cellH*10),
+			int32(cellW*2),
+			int32(cellH*6),
+			"platformLine"))
+
 		// 来点阻碍的线段
 		line = resolv.NewLine(
 			int32(game.W/4+cellW*10),
@@ -159,6 +315,7 @@ func NewDemo(w, h float32) *scene.Scene {
 						nil,
 						resource.GetTexturesByName("spike"))
 					spike.AddTags("dangerous", "isSpike")
+					spike.SetData(&scene.Hazard{Damage: 1, Knockback: 10})
 					game.Map.Add(spike)
 				}
 
@@ -4,11 +4,12 @@ out vec4 FragColor;
 
 uniform sampler2D image;
 uniform vec3 spriteColor;
+uniform float brightness;
 
 void main()
 {
     vec4 texColor = texture(image, TexCoords);
     if(texColor.a < 0.1)
         discard;
-    FragColor = vec4(spriteColor, 1.0) * texColor;
+    FragColor = vec4(spriteColor * brightness, 1.0) * texColor;
 }
\ No newline at end of file
@@ -7,6 +7,7 @@ uniform mat4 model;
 uniform mat4 projection;
 uniform mat4 view;
 uniform int reverseX;
+uniform vec4 uvRect; // <vec2 offset, vec2 scale>
 
 void main()
 {   if(reverseX == 1){
@@ -14,5 +15,6 @@ void main()
     }else{
         TexCoords = vec2(1 - vertex.z, vertex.w);
     }
+    TexCoords = uvRect.xy + TexCoords * uvRect.zw;
     gl_Position = projection * view * model * vec4(vertex.x, vertex.y, 0.0, 1.0);
 }
\ No newline at end of file
@@ -55,6 +55,10 @@ func (shader *Shader) SetMatrix4fv(name string, value *float32) {
 func (shader *Shader) SetVector3f(name string, vec3 mgl32.Vec3) {
 	gl.Uniform3f(gl.GetUniformLocation(shader.ID, gl.Str(name+"\x00")), vec3[0], vec3[1], vec3[2])
 }
+
+func (shader *Shader) SetVector4f(name string, vec4 mgl32.Vec4) {
+	gl.Uniform4f(gl.GetUniformLocation(shader.ID, gl.Str(name+"\x00")), vec4[0], vec4[1], vec4[2], vec4[3])
+}
 func compile(sourceString string, shaderType uint32) (uint32, error) {
 	shader := gl.CreateShader(shaderType)
 	source, free := gl.Strs(sourceString)
@@ -12,6 +12,8 @@ import (
 type Texture2D struct {
 	ID           uint32
 	TEXTUREINDEX uint32
+	// 纹理图片尺寸
+	W, H int32
 }
 
 func NewTexture2D(file string, TEXTUREINDEX uint32) *Texture2D {
@@ -48,7 +50,12 @@ func NewTexture2D(file string, TEXTUREINDEX uint32) *Texture2D {
 		gl.UNSIGNED_BYTE,
 		gl.Ptr(rgba.Pix))
 	gl.BindTexture(gl.TEXTURE_2D, 0)
-	return &Texture2D{ID: textureID, TEXTUREINDEX: TEXTUREINDEX}
+	return &Texture2D{
+		ID:           textureID,
+		TEXTUREINDEX: TEXTUREINDEX,
+		W:            int32(rgba.Rect.Size().X),
+		H:            int32(rgba.Rect.Size().Y),
+	}
 }
 
 func (texture *Texture2D) Use() {